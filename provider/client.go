@@ -2,107 +2,407 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	defaultAPIPrefix      = "/api/v2"
+	defaultRequestTimeout = 60 * time.Second
+	defaultMaxRetries     = 3
+	defaultRetryWaitMin   = 1 * time.Second
+	defaultRetryWaitMax   = 30 * time.Second
 )
 
 type Client struct {
-	Host   string
-	Token  string
-	Client *http.Client
+	Host     string
+	Token    string
+	Username string
+	Password string
+	Client   *http.Client
+
+	// APIPrefix is prepended to every request path, e.g. "/api/v2" for AWX or
+	// "/api/controller/v2" for an Ansible Automation Platform 2.5 gateway.
+	APIPrefix string
+
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// sessionTokenID is the ID of the OAuth2 token created by ExchangeToken.
+	sessionTokenID string
 }
 
-func NewClient(host, token string) (*Client, error) {
-	if host == "" || token == "" {
-		return nil, errors.New("host and token must be provided")
+// NewClient returns a client authenticating with token when it is set, and
+// with HTTP basic auth using username and password otherwise.
+func NewClient(host, token, username, password string) (*Client, error) {
+	if host == "" {
+		return nil, errors.New("host must be provided")
+	}
+	if token == "" && (username == "" || password == "") {
+		return nil, errors.New("either token or username and password must be provided")
 	}
 	return &Client{
-		Host:   host,
-		Token:  token,
-		Client: &http.Client{},
+		Host:         strings.TrimSuffix(host, "/"),
+		Token:        token,
+		Username:     username,
+		Password:     password,
+		Client:       &http.Client{Timeout: defaultRequestTimeout},
+		APIPrefix:    defaultAPIPrefix,
+		MaxRetries:   defaultMaxRetries,
+		RetryWaitMin: defaultRetryWaitMin,
+		RetryWaitMax: defaultRetryWaitMax,
 	}, nil
 }
 
-func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
-	url := fmt.Sprintf("%s%s", c.Host, path)
+// DetectAPIPrefix probes the API root and sets APIPrefix to the controller API
+// advertised there. Upstream AWX advertises its "current_version", while an
+// AAP 2.5 gateway lists the controller API among its "apis".
+func (c *Client) DetectAPIPrefix(ctx context.Context) error {
+	resp, err := c.Get(ctx, "/api/")
+	if err != nil {
+		return err
+	}
+	if apis, ok := resp["apis"].(map[string]interface{}); ok {
+		controller, ok := apis["controller"].(string)
+		if !ok {
+			return fmt.Errorf("AWX API root does not list a controller API %v", resp)
+		}
+		resp, err = c.Get(ctx, controller)
+		if err != nil {
+			return err
+		}
+		if _, ok := resp["current_version"].(string); !ok {
+			c.APIPrefix = strings.TrimSuffix(controller, "/") + "/v2"
+			return nil
+		}
+	}
+	current, ok := resp["current_version"].(string)
+	if !ok {
+		return fmt.Errorf("AWX API root does not advertise a current version %v", resp)
+	}
+	c.APIPrefix = strings.TrimSuffix(current, "/")
+	return nil
+}
+
+// ExchangeToken trades the configured username and password for a short-lived
+// OAuth2 token, which is used for all subsequent requests until RevokeToken.
+func (c *Client) ExchangeToken(ctx context.Context) error {
+	if c.Username == "" || c.Password == "" {
+		return errors.New("username and password are required to exchange for a token")
+	}
+	c.Token = ""
+	data := map[string]interface{}{
+		"description": "Terraform provider session",
+		"application": nil,
+		"scope":       "write",
+	}
+	resp, err := c.Post(ctx, "/tokens/", data)
+	if err != nil {
+		return err
+	}
+	token, ok := resp["token"].(string)
+	if !ok || token == "" {
+		return fmt.Errorf("AWX API did not return a token %v", resp)
+	}
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return fmt.Errorf("AWX API did not return an id %v", resp)
+	}
+	c.Token = token
+	c.sessionTokenID = fmt.Sprintf("%.0f", id)
+	return nil
+}
+
+// RevokeToken deletes the token created by ExchangeToken, if any.
+func (c *Client) RevokeToken(ctx context.Context) error {
+	if c.sessionTokenID == "" {
+		return nil
+	}
+	err := c.Delete(ctx, fmt.Sprintf("/tokens/%s/", c.sessionTokenID))
+	if err != nil && !c.IsNotFound(err) {
+		return err
+	}
+	c.Token = ""
+	c.sessionTokenID = ""
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	url := fmt.Sprintf("%s%s", c.Host, c.resolvePath(path))
 	var buf bytes.Buffer
 	if body != nil {
 		if err := json.NewEncoder(&buf).Encode(body); err != nil {
 			return nil, err
 		}
 	}
-	req, err := http.NewRequest(method, url, &buf)
+	req, err := http.NewRequestWithContext(ctx, method, url, &buf)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	if c.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	} else {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
 	return req, nil
 }
 
+// resolvePath prepends the API prefix to path. Paths already rooted at "/api/",
+// such as the pagination and related links returned by AWX, are used as-is.
+func (c *Client) resolvePath(path string) string {
+	if strings.HasPrefix(path, "/api/") {
+		return path
+	}
+	return c.APIPrefix + path
+}
+
 func (c *Client) do(req *http.Request) (map[string]interface{}, error) {
-	resp, err := c.Client.Do(req)
+	bodyBytes, err := c.doRaw(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+
+	var result map[string]interface{}
+	if len(bodyBytes) > 0 {
+		if err := json.Unmarshal(bodyBytes, &result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// doRaw sends req, retrying transient failures, and returns the response body.
+func (c *Client) doRaw(req *http.Request) ([]byte, error) {
+	var (
+		resp      *http.Response
+		bodyBytes []byte
+		err       error
+	)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		resp, bodyBytes, err = c.send(req)
+		if attempt >= c.MaxRetries || !c.shouldRetry(req, resp, err) {
+			break
+		}
+		if err := sleepContext(req.Context(), c.backoff(attempt, resp)); err != nil {
+			return nil, err
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// Check for non-200 status codes
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(req, resp.StatusCode, bodyBytes)
 	}
+	return bodyBytes, nil
+}
 
-	var result map[string]interface{}
-	if len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, &result); err != nil {
-			return nil, err
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, bodyBytes, nil
+}
+
+// shouldRetry decides whether a request is worth another attempt. 429 and 503
+// mean the request was turned away before AWX acted on it, so they are retried
+// for any method, as are dial failures such as "connection refused", which
+// happen before anything is sent. Other connection failures and other 5xx
+// responses, including 502 and 504 from a proxy that may have forwarded the
+// request, are only retried for idempotent methods, since AWX may have already
+// applied the change.
+func (c *Client) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
 		}
+		return isIdempotent(req.Method)
 	}
-	return result, nil
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && isIdempotent(req.Method)
+}
+
+// backoff returns how long to wait before the next attempt, honoring the
+// Retry-After header when AWX or a proxy in front of it sends one.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > c.RetryWaitMax {
+				return c.RetryWaitMax
+			}
+			return wait
+		}
+	}
+	// A shift that overflows ends up below the minimum.
+	wait := c.RetryWaitMin << uint(attempt)
+	if wait < c.RetryWaitMin || wait > c.RetryWaitMax {
+		return c.RetryWaitMax
+	}
+	return wait
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
 }
 
-func (c *Client) Post(path string, body interface{}) (map[string]interface{}, error) {
-	req, err := c.newRequest("POST", path, body)
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) Post(ctx context.Context, path string, body interface{}) (map[string]interface{}, error) {
+	req, err := c.newRequest(ctx, "POST", path, body)
 	if err != nil {
 		return nil, err
 	}
 	return c.do(req)
 }
 
-func (c *Client) Get(path string) (map[string]interface{}, error) {
-	req, err := c.newRequest("GET", path, nil)
+func (c *Client) Get(ctx context.Context, path string) (map[string]interface{}, error) {
+	req, err := c.newRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 	return c.do(req)
 }
 
-func (c *Client) Patch(path string, body interface{}) (map[string]interface{}, error) {
-	req, err := c.newRequest("PATCH", path, body)
+// GetText fetches a plain-text endpoint, such as "/jobs/1/stdout/?format=txt".
+func (c *Client) GetText(ctx context.Context, path string) (string, error) {
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+	bodyBytes, err := c.doRaw(req)
+	if err != nil {
+		return "", err
+	}
+	return string(bodyBytes), nil
+}
+
+// GetAll fetches every page of a list endpoint by following the "next" links
+// returned by AWX and returns the combined results.
+func (c *Client) GetAll(ctx context.Context, path string) ([]interface{}, error) {
+	var results []interface{}
+	for path != "" {
+		resp, err := c.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		page, _ := resp["results"].([]interface{})
+		results = append(results, page...)
+		path, _ = resp["next"].(string)
+	}
+	return results, nil
+}
+
+// GetAllIDs returns the IDs of every object listed by a list endpoint, in the
+// order AWX returns them.
+func (c *Client) GetAllIDs(ctx context.Context, path string) ([]string, error) {
+	results, err := c.GetAll(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, F64ToStr(result.(map[string]interface{})["id"]))
+	}
+	return ids, nil
+}
+
+// Associate adds the object with the given ID to an AWX sub-list endpoint,
+// e.g. "/organizations/1/galaxy_credentials/".
+func (c *Client) Associate(ctx context.Context, path, id string) error {
+	_, err := c.Post(ctx, path, map[string]interface{}{
+		"id": IfaceToInt(id),
+	})
+	return err
+}
+
+// Disassociate removes the object with the given ID from an AWX sub-list endpoint.
+func (c *Client) Disassociate(ctx context.Context, path, id string) error {
+	_, err := c.Post(ctx, path, map[string]interface{}{
+		"id":           IfaceToInt(id),
+		"disassociate": true,
+	})
+	return err
+}
+
+func (c *Client) Patch(ctx context.Context, path string, body interface{}) (map[string]interface{}, error) {
+	req, err := c.newRequest(ctx, "PATCH", path, body)
 	if err != nil {
 		return nil, err
 	}
 	return c.do(req)
 }
 
-func (c *Client) Put(path string, body interface{}) (map[string]interface{}, error) {
-	req, err := c.newRequest("PUT", path, body)
+func (c *Client) Put(ctx context.Context, path string, body interface{}) (map[string]interface{}, error) {
+	req, err := c.newRequest(ctx, "PUT", path, body)
 	if err != nil {
 		return nil, err
 	}
 	return c.do(req)
 }
 
-func (c *Client) Delete(path string) error {
-	req, err := c.newRequest("DELETE", path, nil)
+func (c *Client) Delete(ctx context.Context, path string) error {
+	req, err := c.newRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
@@ -111,8 +411,6 @@ func (c *Client) Delete(path string) error {
 }
 
 func (c *Client) IsNotFound(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), "HTTP 404")
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.IsNotFound()
 }
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	cases := []struct {
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, http.StatusTooManyRequests, nil, true},
+		{http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{http.MethodPost, http.StatusServiceUnavailable, nil, true},
+		{http.MethodPost, http.StatusBadGateway, nil, false},
+		{http.MethodPost, http.StatusGatewayTimeout, nil, false},
+		{http.MethodPost, http.StatusInternalServerError, nil, false},
+		{http.MethodPatch, http.StatusGatewayTimeout, nil, false},
+		{http.MethodGet, http.StatusBadGateway, nil, true},
+		{http.MethodPut, http.StatusGatewayTimeout, nil, true},
+		{http.MethodDelete, http.StatusInternalServerError, nil, true},
+		{http.MethodGet, http.StatusNotFound, nil, false},
+		{http.MethodGet, http.StatusBadRequest, nil, false},
+		{http.MethodGet, 0, errors.New("connection reset"), true},
+		{http.MethodPost, 0, errors.New("connection reset"), false},
+		{http.MethodPost, 0, &url.Error{Op: "Post", URL: "https://awx.example.com/api/v2/job_templates/1/launch/", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}, true},
+		{http.MethodPatch, 0, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}, true},
+		{http.MethodPost, 0, &url.Error{Op: "Post", URL: "https://awx.example.com/api/v2/job_templates/1/launch/", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}, false},
+		{http.MethodGet, 0, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, true},
+	}
+
+	c := &Client{}
+	for _, tc := range cases {
+		req, _ := http.NewRequest(tc.method, "https://awx.example.com/api/v2/ping/", nil)
+		var resp *http.Response
+		if tc.err == nil {
+			resp = &http.Response{StatusCode: tc.status}
+		}
+		if got := c.shouldRetry(req, resp, tc.err); got != tc.want {
+			t.Errorf("shouldRetry(%s, %d, %v) = %v, want %v", tc.method, tc.status, tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestShouldRetryCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://awx.example.com/api/v2/ping/", nil)
+
+	c := &Client{}
+	if c.shouldRetry(req, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil) {
+		t.Error("shouldRetry retried a request whose context is done")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		name       string
+		min, max   time.Duration
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{"first attempt", time.Second, 30 * time.Second, 0, "", time.Second},
+		{"doubles", time.Second, 30 * time.Second, 3, "", 8 * time.Second},
+		{"capped", time.Second, 30 * time.Second, 5, "", 30 * time.Second},
+		{"overflow", time.Second, 30 * time.Second, 70, "", 30 * time.Second},
+		{"zero minimum", 0, 30 * time.Second, 2, "", 0},
+		{"retry after", time.Second, 30 * time.Second, 0, "7", 7 * time.Second},
+		{"retry after capped", time.Second, 30 * time.Second, 0, "120", 30 * time.Second},
+		{"invalid retry after", time.Second, 30 * time.Second, 1, "soon", 2 * time.Second},
+	}
+
+	for _, tc := range cases {
+		c := &Client{RetryWaitMin: tc.min, RetryWaitMax: tc.max}
+		resp := &http.Response{Header: http.Header{}}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+		if got := c.backoff(tc.attempt, resp); got != tc.want {
+			t.Errorf("%s: backoff(%d) = %s, want %s", tc.name, tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"15", 15 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+	}
+
+	for _, tc := range cases {
+		got, ok := parseRetryAfter(tc.value)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tc.value, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
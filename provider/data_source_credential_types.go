@@ -1,14 +1,15 @@
 package provider
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceCredentialTypes() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCredentialTypesRead,
+		ReadContext: dataSourceCredentialTypesRead,
 
 		Description: "Retrieves all available credential types from AWX/Tower. Credential types define the various " +
 			"authentication mechanisms available for use in credentials. Each type specifies what information is required " +
@@ -29,19 +30,19 @@ func dataSourceCredentialTypes() *schema.Resource {
 	}
 }
 
-func dataSourceCredentialTypesRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceCredentialTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
-	resp, err := clientInstance.Get("/api/v2/credential_types?page_size=100")
+	results, err := clientInstance.GetAll(ctx, "/credential_types/?page_size=100")
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX instance: %s", err)
+		return diag.Errorf("failed to read AWX instance: %s", err)
 	}
 
 	mapTypes := make(map[string]int)
-	for _, credentialType := range resp["results"].([]interface{}) {
+	for _, credentialType := range results {
 		ct := credentialType.(map[string]interface{})
 		mapTypes[ct["name"].(string)] = int(ct["id"].(float64))
 	}
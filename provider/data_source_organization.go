@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrganizationRead,
+
+		Description: "Looks up an existing AWX/Tower organization by name. This data source is useful when you need to " +
+			"reference the ID of an organization that is not managed by Terraform, for example when creating inventories " +
+			"or projects in it.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the organization to look up.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the organization.",
+			},
+			"max_hosts": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of hosts allowed to be managed by the organization. 0 means no limit.",
+			},
+			"default_environment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the default execution environment of the organization, if any.",
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	name := d.Get("name").(string)
+
+	results, err := clientInstance.GetAll(ctx, fmt.Sprintf("/organizations/?name=%s", url.QueryEscape(name)))
+	if err != nil {
+		return diag.Errorf("failed to read AWX organization: %s", err)
+	}
+	if len(results) != 1 {
+		return diag.Errorf("expected exactly one AWX organization named %q, found %d", name, len(results))
+	}
+
+	org := results[0].(map[string]interface{})
+	d.SetId(F64ToStr(org["id"]))
+	d.Set("description", org["description"].(string))
+	d.Set("max_hosts", org["max_hosts"])
+	d.Set("default_environment", NullableF64ToStr(org["default_environment"]))
+	return nil
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// diagFromErr converts err into diagnostics summarised by summary. Field-level
+// validation errors returned by AWX become one diagnostic per field, pointing
+// at the attribute of the same name, or at the one given in fields when the
+// attribute is named differently from the AWX field (e.g. "inventory" is
+// exposed as "inventory_id").
+func diagFromErr(summary string, err error, fields map[string]string) diag.Diagnostics {
+	apiErr, ok := AsAPIError(err)
+	if !ok || len(apiErr.FieldErrors) == 0 {
+		return diag.Errorf("%s: %s", summary, err)
+	}
+
+	names := make([]string, 0, len(apiErr.FieldErrors))
+	for name := range apiErr.FieldErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diags diag.Diagnostics
+	for _, name := range names {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        fmt.Sprintf("AWX rejected %s: %s", name, strings.Join(apiErr.FieldErrors[name], " ")),
+			AttributePath: attributePath(name, fields),
+		})
+	}
+	return diags
+}
+
+// attributePath maps an AWX field name such as "name" or "inputs.username" to
+// the path of the matching attribute. Errors not tied to a field map to nil.
+func attributePath(field string, fields map[string]string) cty.Path {
+	if field == "__all__" || field == "non_field_errors" {
+		return nil
+	}
+	parts := strings.Split(field, ".")
+	attr := parts[0]
+	if renamed, ok := fields[attr]; ok {
+		attr = renamed
+	}
+	if attr == "" {
+		return nil
+	}
+	path := cty.GetAttrPath(attr)
+	for _, key := range parts[1:] {
+		path = path.IndexString(key)
+	}
+	return path
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned by the client when AWX answers with an error status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Body       string
+	// Detail holds the "detail" message AWX returns for non-validation errors,
+	// e.g. "Not found." or "Authentication credentials were not provided.".
+	Detail string
+	// FieldErrors holds field-level validation errors keyed by AWX field name,
+	// e.g. {"name": ["This field is required."]}. Nested fields are joined with
+	// a dot, e.g. "inputs.username".
+	FieldErrors map[string][]string
+}
+
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode:  statusCode,
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Body:        string(body),
+		FieldErrors: map[string][]string{},
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return e
+	}
+	for field, value := range payload {
+		if detail, ok := value.(string); ok && field == "detail" {
+			e.Detail = detail
+			continue
+		}
+		collectFieldErrors(e.FieldErrors, field, value)
+	}
+	return e
+}
+
+func collectFieldErrors(out map[string][]string, field string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		out[field] = append(out[field], v)
+	case []interface{}:
+		for _, item := range v {
+			collectFieldErrors(out, field, item)
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			collectFieldErrors(out, field+"."+key, item)
+		}
+	}
+}
+
+func (e *APIError) Error() string {
+	msg := e.Body
+	switch {
+	case len(e.FieldErrors) > 0:
+		fields := make([]string, 0, len(e.FieldErrors))
+		for field := range e.FieldErrors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		parts := make([]string, 0, len(fields))
+		for _, field := range fields {
+			parts = append(parts, fmt.Sprintf("%s: %s", field, strings.Join(e.FieldErrors[field], " ")))
+		}
+		msg = strings.Join(parts, "; ")
+	case e.Detail != "":
+		msg = e.Detail
+	}
+	return fmt.Sprintf("HTTP %d on %s %s: %s", e.StatusCode, e.Method, e.Path, msg)
+}
+
+// IsBadRequest reports whether AWX rejected the request payload (HTTP 400).
+func (e *APIError) IsBadRequest() bool { return e.StatusCode == http.StatusBadRequest }
+
+// IsUnauthorized reports whether the credentials were missing or invalid (HTTP 401).
+func (e *APIError) IsUnauthorized() bool { return e.StatusCode == http.StatusUnauthorized }
+
+// IsForbidden reports whether the caller lacks permission for the request (HTTP 403).
+func (e *APIError) IsForbidden() bool { return e.StatusCode == http.StatusForbidden }
+
+// IsNotFound reports whether the requested object does not exist (HTTP 404).
+func (e *APIError) IsNotFound() bool { return e.StatusCode == http.StatusNotFound }
+
+// IsConflict reports whether the request conflicts with the object state (HTTP 409),
+// e.g. deleting a project while an update is running.
+func (e *APIError) IsConflict() bool { return e.StatusCode == http.StatusConflict }
+
+// IsServerError reports whether AWX failed to handle the request (HTTP 5xx).
+func (e *APIError) IsServerError() bool { return e.StatusCode >= http.StatusInternalServerError }
+
+// AsAPIError returns the *APIError wrapped in err, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
@@ -14,3 +14,11 @@ func IfaceToInt(i interface{}) int {
 func F64ToStr(i interface{}) string {
 	return fmt.Sprintf("%.0f", i.(float64))
 }
+
+func NullableF64ToStr(i interface{}) string {
+	// AWX returns null for unset foreign keys
+	if i == nil {
+		return ""
+	}
+	return F64ToStr(i)
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// hostFilterParser checks the syntax of an AWX smart inventory host filter,
+// e.g. `name__icontains=web and (groups__name=prod or not enabled=false)`.
+// It mirrors the grammar AWX accepts so that mistakes are reported at plan
+// time instead of when AWX rejects the inventory:
+//
+//	expr    = andExpr { "or" andExpr }
+//	andExpr = notExpr { "and" notExpr }
+//	notExpr = "not" notExpr | "(" expr ")" | term
+//	term    = key "=" value
+//
+// Keys are field names joined with "__", optionally ending with a lookup
+// such as "icontains"; values are bare words or single or double quoted
+// strings.
+type hostFilterParser struct {
+	input string
+	pos   int
+}
+
+// validateHostFilter returns an error describing the first syntax error in
+// filter, if any.
+func validateHostFilter(filter string) error {
+	p := &hostFilterParser{input: filter}
+	if p.atEnd() {
+		return errors.New("host filter is empty")
+	}
+	if err := p.parseExpr(); err != nil {
+		return err
+	}
+	if !p.atEnd() {
+		return p.errorf("unexpected %q", p.rest())
+	}
+	return nil
+}
+
+func (p *hostFilterParser) parseExpr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for p.keyword("or") {
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *hostFilterParser) parseAnd() error {
+	if err := p.parseNot(); err != nil {
+		return err
+	}
+	for p.keyword("and") {
+		if err := p.parseNot(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *hostFilterParser) parseNot() error {
+	if p.keyword("not") {
+		return p.parseNot()
+	}
+	if p.atEnd() {
+		return p.errorf("expected a key=value term")
+	}
+	if p.input[p.pos] == '(' {
+		p.pos++
+		if err := p.parseExpr(); err != nil {
+			return err
+		}
+		if p.atEnd() || p.input[p.pos] != ')' {
+			return p.errorf("expected closing parenthesis")
+		}
+		p.pos++
+		return nil
+	}
+	return p.parseTerm()
+}
+
+func (p *hostFilterParser) parseTerm() error {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r, size := p.peek()
+		if !isHostFilterKeyChar(r) {
+			break
+		}
+		p.pos += size
+	}
+	key := p.input[start:p.pos]
+	if key == "" {
+		return p.errorf("expected a key=value term, got %q", p.rest())
+	}
+	if strings.HasPrefix(key, "__") || strings.HasSuffix(key, "__") {
+		p.pos = start
+		return p.errorf("invalid key %q", key)
+	}
+	if p.pos >= len(p.input) || p.input[p.pos] != '=' {
+		p.pos = start
+		return p.errorf("expected '=' after key %q", key)
+	}
+	p.pos++
+
+	if p.pos < len(p.input) && (p.input[p.pos] == '"' || p.input[p.pos] == '\'') {
+		quote := p.input[p.pos]
+		end := strings.IndexByte(p.input[p.pos+1:], quote)
+		if end < 0 {
+			return p.errorf("unterminated quoted value")
+		}
+		p.pos += end + 2
+		return nil
+	}
+	valueStart := p.pos
+	for p.pos < len(p.input) {
+		r, size := p.peek()
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == valueStart {
+		return p.errorf("missing value for key %q", key)
+	}
+	return nil
+}
+
+// keyword consumes the given operator if it is the next word in the input.
+func (p *hostFilterParser) keyword(word string) bool {
+	if p.atEnd() {
+		return false
+	}
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], word) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(p.input[end:]); end < len(p.input) && !unicode.IsSpace(r) && r != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// atEnd skips whitespace and reports whether the input is exhausted.
+func (p *hostFilterParser) atEnd() bool {
+	for p.pos < len(p.input) {
+		r, size := p.peek()
+		if !unicode.IsSpace(r) {
+			break
+		}
+		p.pos += size
+	}
+	return p.pos >= len(p.input)
+}
+
+// peek decodes the rune at the current position and returns it with its size.
+func (p *hostFilterParser) peek() (rune, int) {
+	return utf8.DecodeRuneInString(p.input[p.pos:])
+}
+
+func (p *hostFilterParser) rest() string {
+	return p.input[p.pos:]
+}
+
+func (p *hostFilterParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid host filter at position %d: %s", utf8.RuneCountInString(p.input[:p.pos])+1, fmt.Sprintf(format, args...))
+}
+
+func isHostFilterKeyChar(r rune) bool {
+	return r == '_' || r == '.' || r == '-' || r == '[' || r == ']' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import "testing"
+
+func TestValidateHostFilter(t *testing.T) {
+	valid := []string{
+		"name=web01",
+		"name__icontains=web and groups__name=prod",
+		"not enabled=false",
+		"NOT enabled=false OR name=db",
+		"(name=web or name=db) and groups__name='prod east'",
+		`ansible_facts__ansible_distribution="Ubuntu"`,
+		"name=web and(groups__name=prod)",
+		"notes=backup",
+		"nåme=x",
+		"name=wëb",
+	}
+	for _, filter := range valid {
+		if err := validateHostFilter(filter); err != nil {
+			t.Errorf("validateHostFilter(%q) returned %v, want nil", filter, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"":             "host filter is empty",
+		"   ":          "host filter is empty",
+		"name":         `invalid host filter at position 1: expected '=' after key "name"`,
+		"name=":        `invalid host filter at position 6: missing value for key "name"`,
+		"a=1 b=2":      `invalid host filter at position 5: unexpected "b=2"`,
+		"(a=1":         "invalid host filter at position 5: expected closing parenthesis",
+		"a=1)":         `invalid host filter at position 4: unexpected ")"`,
+		"a=1 and":      "invalid host filter at position 8: expected a key=value term",
+		"a='x":         "invalid host filter at position 3: unterminated quoted value",
+		"__a=1":        `invalid host filter at position 1: invalid key "__a"`,
+		"nåme":         `invalid host filter at position 1: expected '=' after key "nåme"`,
+		"é=1 and ñ":    `invalid host filter at position 9: expected '=' after key "ñ"`,
+		"name=x and =": `invalid host filter at position 12: expected a key=value term, got "="`,
+	}
+	for filter, want := range invalid {
+		err := validateHostFilter(filter)
+		if err == nil {
+			t.Errorf("validateHostFilter(%q) returned nil, want %q", filter, want)
+			continue
+		}
+		if err.Error() != want {
+			t.Errorf("validateHostFilter(%q) returned %q, want %q", filter, err, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importByNamedURL returns an importer for objects living under collectionPath,
+// e.g. "/job_templates/". The import ID may be a numeric ID or an AWX named
+// URL such as "Deploy++Default" (name++organization).
+func importByNamedURL(collectionPath string) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			id, err := resolveID(ctx, m.(*Client), collectionPath, d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+// resolveID returns ref when it is a numeric ID, and otherwise looks it up as
+// a named URL under collectionPath and returns the ID of the matching object.
+func resolveID(ctx context.Context, c *Client, collectionPath, ref string) (string, error) {
+	if _, err := strconv.Atoi(ref); err == nil {
+		return ref, nil
+	}
+	resp, err := c.Get(ctx, collectionPath+url.PathEscape(ref)+"/")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %q: %w", ref, err)
+	}
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return "", fmt.Errorf("AWX API did not return an id %v", resp)
+	}
+	return fmt.Sprintf("%.0f", id), nil
+}
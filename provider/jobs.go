@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jobPollInterval is how often a running AWX job is polled for its status.
+const jobPollInterval = 5 * time.Second
+
+// jobFinished reports whether status is a terminal AWX unified job status.
+func jobFinished(status string) bool {
+	switch status {
+	case "successful", "failed", "error", "canceled":
+		return true
+	}
+	return false
+}
+
+// waitForJob polls the unified job (job, project update, inventory update...)
+// at path until it reaches a terminal status and returns its final state. It
+// gives up when ctx is done, which is how resource timeouts bound the wait.
+func waitForJob(ctx context.Context, c *Client, path string) (map[string]interface{}, error) {
+	for {
+		job, err := c.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if status, _ := job["status"].(string); jobFinished(status) {
+			return job, nil
+		}
+		if err := sleepContext(ctx, jobPollInterval); err != nil {
+			return nil, fmt.Errorf("gave up waiting for %s to finish: %s", path, err)
+		}
+	}
+}
+
+// jobFailureStdoutLines is how many trailing lines of stdout are included in
+// the error of a failed job when it has no traceback.
+const jobFailureStdoutLines = 20
+
+// jobError returns an error describing why a finished job did not succeed, or
+// nil when it was successful. The error carries the job's result_traceback, or
+// failing that the tail of its stdout. stdout is output the caller already
+// downloaded; when it is empty, the output is downloaded here.
+func jobError(ctx context.Context, c *Client, job map[string]interface{}, stdout string) error {
+	status, _ := job["status"].(string)
+	if status == "successful" {
+		return nil
+	}
+	msg := fmt.Sprintf("job %s finished with status %q", F64ToStr(job["id"]), status)
+	if explanation, _ := job["job_explanation"].(string); explanation != "" {
+		msg += ": " + explanation
+	}
+
+	if traceback, _ := job["result_traceback"].(string); traceback != "" {
+		msg += "\n\n" + traceback
+	} else {
+		if stdout == "" {
+			stdout, _ = jobStdout(ctx, c, job)
+		}
+		if stdout != "" {
+			msg += "\n\n" + tailLines(stdout, jobFailureStdoutLines)
+		}
+	}
+	return errors.New(msg)
+}
+
+// jobStdout returns the plain-text output of a unified job. The download
+// format is used because "txt" replaces output larger than AWX's
+// STDOUT_MAX_BYTES_DISPLAY with a notice, which is exactly when the tail
+// matters most.
+func jobStdout(ctx context.Context, c *Client, job map[string]interface{}) (string, error) {
+	related, _ := job["related"].(map[string]interface{})
+	path, ok := related["stdout"].(string)
+	if !ok {
+		return "", fmt.Errorf("AWX API did not return a stdout link %v", related)
+	}
+	return c.GetText(ctx, path+"?format=txt_download")
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
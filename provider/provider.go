@@ -1,9 +1,13 @@
 package provider
 
 import (
-	"fmt"
+	"context"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func New() *schema.Provider {
@@ -17,36 +21,164 @@ func New() *schema.Provider {
 			},
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The OAuth2 token or Personal Access Token for authenticating with AWX/Tower. This token must have sufficient permissions to perform the requested operations.",
+				Optional:    true,
+				Description: "The OAuth2 token or Personal Access Token for authenticating with AWX/Tower. This token must have sufficient permissions to perform the requested operations. Takes precedence over username and password.",
 				DefaultFunc: schema.EnvDefaultFunc("AWX_TOKEN", nil),
 				Sensitive:   true,
 			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The username for authenticating with AWX/Tower using HTTP basic auth. Used together with password when no token is set.",
+				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", nil),
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The password for authenticating with AWX/Tower using HTTP basic auth. Used together with username when no token is set.",
+				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", nil),
+				Sensitive:   true,
+			},
+			"exchange_token": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, username and password are exchanged for a short-lived OAuth2 token when the provider is configured, and the token is revoked when the provider shuts down. Revocation is best-effort: a token that cannot be revoked within a second of shutdown is left to expire in AWX. Ignored when token is set.",
+			},
+			"api_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path prefix of the controller API, e.g. '/api/v2' for AWX or '/api/controller/v2' for an Ansible Automation Platform 2.5 gateway. If not set, it is detected by probing '/api/' when the provider is configured.",
+				DefaultFunc: schema.EnvDefaultFunc("AWX_API_PREFIX", nil),
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM-encoded CA certificate bundle used to verify the AWX/Tower server certificate, in addition to the system trust store.",
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CA_CERT_FILE", nil),
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA certificate bundle used to verify the AWX/Tower server certificate, in addition to the system trust store.",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM-encoded client certificate presented to AWX/Tower for mutual TLS. Requires client_key.",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM-encoded private key for client_cert.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, the AWX/Tower server certificate is not verified. Only use this against test instances with self-signed certificates.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultRequestTimeout / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds a single HTTP request to AWX/Tower may take, including reading the response. Set to 0 to disable the limit.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request is retried when AWX is temporarily unavailable. HTTP 429 and 503 responses and failures to connect are retried for any request; other connection errors and other 5xx responses, including 502 and 504, only for idempotent requests such as GET, PUT and DELETE. Set to 0 to disable retries.",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultRetryWaitMin / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time in seconds to wait before retrying a request. The wait doubles with each attempt, up to retry_wait_max.",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultRetryWaitMax / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds to wait before retrying a request. Also caps the delay requested by a Retry-After header.",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"awx_credential_types": dataSourceCredentialTypes(),
+			"awx_organization":     dataSourceOrganization(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
+			"awx_constructed_inventory":    ResourceConstructedInventory(),
 			"awx_credentials":              ResourceCredentials(),
+			"awx_organization":             ResourceOrganization(),
 			"awx_inventory":                ResourceInventory(),
+			"awx_inventory_group":          ResourceInventoryGroup(),
 			"awx_inventory_host":           ResourceInventoryHost(),
+			"awx_inventory_hosts":          ResourceInventoryHosts(),
+			"awx_inventory_source":         ResourceInventorySource(),
+			"awx_inventory_source_update":  ResourceInventorySourceUpdate(),
 			"awx_project":                  ResourceProject(),
+			"awx_role_assignment":          ResourceRoleAssignment(),
+			"awx_team":                     ResourceTeam(),
+			"awx_team_membership":          ResourceTeamMembership(),
+			"awx_user":                     ResourceUser(),
 			"awx_job_template":             ResourceJobTemplate(),
 			"awx_job_template_schedule":    ResourceJobTemplateSchedule(),
 			"awx_job_template_launch":      ResourceJobTemplateLaunch(),
 			"awx_job_template_credentials": ResourceJobTemplateCredential(),
 		},
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	host := d.Get("host").(string)
 	token := d.Get("token").(string)
+	username := d.Get("username").(string)
+	password := d.Get("password").(string)
+
+	clientInstance, err := NewClient(host, token, username, password)
+	if err != nil {
+		return nil, diag.Errorf("failed to create AWX  %s", err)
+	}
 
-	clientInstance, err := NewClient(host, token)
+	err = clientInstance.SetTLSOptions(TLSOptions{
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		ClientCert:         d.Get("client_cert").(string),
+		ClientKey:          d.Get("client_key").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create AWX  %s", err)
+		return nil, diag.Errorf("failed to configure TLS for AWX: %s", err)
+	}
+
+	clientInstance.Client.Timeout = time.Duration(d.Get("request_timeout").(int)) * time.Second
+	clientInstance.MaxRetries = d.Get("max_retries").(int)
+	clientInstance.RetryWaitMin = time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+	clientInstance.RetryWaitMax = time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+	if clientInstance.RetryWaitMin > clientInstance.RetryWaitMax {
+		return nil, diag.Errorf("retry_wait_min (%d) must not be greater than retry_wait_max (%d)", d.Get("retry_wait_min"), d.Get("retry_wait_max"))
+	}
+
+	if apiPrefix := d.Get("api_prefix").(string); apiPrefix != "" {
+		clientInstance.APIPrefix = "/" + strings.Trim(apiPrefix, "/")
+	} else if err := clientInstance.DetectAPIPrefix(ctx); err != nil {
+		return nil, diag.Errorf("failed to detect AWX API prefix: %s", err)
+	}
+
+	if token == "" && d.Get("exchange_token").(bool) {
+		if err := clientInstance.ExchangeToken(ctx); err != nil {
+			return nil, diag.Errorf("failed to exchange AWX credentials for a token: %s", err)
+		}
+		sessions.add(clientInstance)
 	}
 	return clientInstance, nil
 }
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceConstructedInventory() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConstructedInventoryCreate,
+		ReadContext:   resourceConstructedInventoryRead,
+		UpdateContext: resourceConstructedInventoryUpdate,
+		DeleteContext: resourceConstructedInventoryDelete,
+		Importer:      importByNamedURL("/constructed_inventories/"),
+		Description: "Manages an Ansible AWX/Tower constructed inventory. A constructed inventory combines the hosts of " +
+			"its input inventories and uses the 'constructed' inventory plugin to build groups and variables from " +
+			"them. AWX/Tower creates the inventory source that performs the construction automatically; its settings " +
+			"are managed through this resource.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this inventory. Used to identify the inventory in the AWX/Tower interface.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Optional description of this inventory.",
+			},
+			"organization": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the organization the inventory belongs to, e.g. from an awx_organization resource or data source.",
+			},
+			"input_inventories": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "Ordered list of the IDs of the inventories whose hosts are combined. When a host appears in several inputs, the variables of later inventories take precedence.",
+			},
+			"source_vars": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Configuration of the 'constructed' inventory plugin in JSON or YAML format, e.g. its 'groups' and 'compose' options.",
+			},
+			"limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Host pattern restricting which hosts of the input inventories are included, using ansible's --limit syntax.",
+			},
+			"update_cache_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Time in seconds a construction is considered current, so that jobs launched within that window do not run it again.",
+			},
+			"verbosity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 2),
+				Description:  "Verbosity of the inventory construction output (0-2).",
+			},
+			"variables": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Inventory variables in JSON or YAML format. These variables will be available to all hosts in this inventory.",
+			},
+			"inventory_source_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the inventory source AWX/Tower created to construct the inventory.",
+			},
+		},
+	}
+}
+
+func constructedInventoryData(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 d.Get("name").(string),
+		"description":          d.Get("description").(string),
+		"organization":         IfaceToInt(d.Get("organization")),
+		"source_vars":          d.Get("source_vars").(string),
+		"limit":                d.Get("limit").(string),
+		"update_cache_timeout": d.Get("update_cache_timeout"),
+		"verbosity":            d.Get("verbosity"),
+		"variables":            d.Get("variables").(string),
+	}
+}
+
+func resourceConstructedInventoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	resp, err := clientInstance.Post(ctx, "/constructed_inventories/", constructedInventoryData(d))
+	if err != nil {
+		return diagFromErr("failed to create AWX constructed inventory", err, nil)
+	}
+
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return diag.Errorf("AWX API did not return an id %v", resp)
+	}
+	d.SetId(fmt.Sprintf("%.0f", id))
+
+	for _, inputID := range d.Get("input_inventories").([]interface{}) {
+		err := clientInstance.Associate(ctx, fmt.Sprintf("/inventories/%s/input_inventories/", d.Id()), inputID.(string))
+		if err != nil {
+			return diagFromErr("failed to add input inventory to AWX constructed inventory", err, nil)
+		}
+	}
+	return resourceConstructedInventoryRead(ctx, d, m)
+}
+
+func resourceConstructedInventoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/constructed_inventories/%s/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX constructed inventory: %s", err)
+	}
+
+	inputInventories, err := clientInstance.GetAllIDs(ctx, fmt.Sprintf("/inventories/%s/input_inventories/", id))
+	if err != nil {
+		return diag.Errorf("failed to read AWX constructed inventory inputs: %s", err)
+	}
+
+	sources, err := clientInstance.GetAllIDs(ctx, fmt.Sprintf("/inventories/%s/inventory_sources/", id))
+	if err != nil {
+		return diag.Errorf("failed to read AWX constructed inventory source: %s", err)
+	}
+	inventorySourceID := ""
+	if len(sources) > 0 {
+		inventorySourceID = sources[0]
+	}
+
+	d.Set("name", resp["name"].(string))
+	d.Set("description", resp["description"].(string))
+	d.Set("organization", F64ToStr(resp["organization"]))
+	d.Set("source_vars", resp["source_vars"])
+	d.Set("limit", resp["limit"])
+	d.Set("update_cache_timeout", resp["update_cache_timeout"])
+	d.Set("verbosity", resp["verbosity"])
+	d.Set("variables", resp["variables"].(string))
+	d.Set("input_inventories", inputInventories)
+	d.Set("inventory_source_id", inventorySourceID)
+	return nil
+}
+
+func resourceConstructedInventoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/constructed_inventories/%s/", id), constructedInventoryData(d))
+	if err != nil {
+		return diagFromErr("failed to update AWX constructed inventory", err, nil)
+	}
+
+	if d.HasChange("input_inventories") {
+		// Input inventories are ordered and AWX appends on association, so
+		// re-associate the whole list to apply the configured order.
+		path := fmt.Sprintf("/inventories/%s/input_inventories/", id)
+		old, _ := d.GetChange("input_inventories")
+		for _, inputID := range old.([]interface{}) {
+			if err := clientInstance.Disassociate(ctx, path, inputID.(string)); err != nil && !clientInstance.IsNotFound(err) {
+				return diagFromErr("failed to remove input inventory from AWX constructed inventory", err, nil)
+			}
+		}
+		for _, inputID := range d.Get("input_inventories").([]interface{}) {
+			if err := clientInstance.Associate(ctx, path, inputID.(string)); err != nil {
+				return diagFromErr("failed to add input inventory to AWX constructed inventory", err, nil)
+			}
+		}
+	}
+	return resourceConstructedInventoryRead(ctx, d, m)
+}
+
+func resourceConstructedInventoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/constructed_inventories/%s/", id))
+	if err != nil {
+		return diag.Errorf("failed to delete AWX constructed inventory: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -1,18 +1,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceCredentials() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCredentialsCreate,
-		Read:   resourceCredentialsRead,
-		Update: resourceCredentialsUpdate,
-		Delete: resourceCredentialsDelete,
+		CreateContext: resourceCredentialsCreate,
+		ReadContext:   resourceCredentialsRead,
+		UpdateContext: resourceCredentialsUpdate,
+		DeleteContext: resourceCredentialsDelete,
+		Importer:      importByNamedURL("/credentials/"),
 		Description: "Manages credentials in Ansible AWX/Tower. Credentials are utilized by Tower for authentication " +
 			"when launching jobs against machines, synchronizing with inventory sources, and importing project content from " +
 			"version control systems. Different credential types support different authentication methods (SSH keys, " +
@@ -51,7 +54,7 @@ func ResourceCredentials() *schema.Resource {
 	}
 }
 
-func resourceCredentialsCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"name":        d.Get("name").(string),
@@ -63,39 +66,40 @@ func resourceCredentialsCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	data["credential_type"] = IfaceToInt(d.Get("credential_type"))
 
-	resp, err := clientInstance.Post("/api/v2/credentials/", data)
+	resp, err := clientInstance.Post(ctx, "/credentials/", data)
 	if err != nil {
-		return fmt.Errorf("failed to create AWX credentials: %s", err)
+		return diagFromErr("failed to create AWX credentials", err, nil)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceCredentialsRead(d, m)
+	return resourceCredentialsRead(ctx, d, m)
 }
 
-func resourceCredentialsRead(d *schema.ResourceData, m interface{}) error {
+func resourceCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/credentials/%s/", id))
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/credentials/%s/", id))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX credentials: %s", err)
+		return diag.Errorf("failed to read AWX credentials: %s", err)
 	}
 
 	d.Set("name", resp["name"])
 	d.Set("description", resp["description"])
-	d.Set("organization", resp["organization"])
+	d.Set("organization", NullableF64ToStr(resp["organization"]))
+	d.Set("credential_type", F64ToStr(resp["credential_type"]))
 	return nil
 }
 
-func resourceCredentialsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCredentialsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
@@ -111,20 +115,20 @@ func resourceCredentialsUpdate(d *schema.ResourceData, m interface{}) error {
 	credential_type, _ := strconv.Atoi(d.Get("credential_type").(string))
 	data["credential_type"] = credential_type
 
-	_, err := clientInstance.Put(fmt.Sprintf("/api/v2/credentials/%s/", id), data)
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/credentials/%s/", id), data)
 	if err != nil {
-		return fmt.Errorf("failed to update AWX credentials: %s", err)
+		return diagFromErr("failed to update AWX credentials", err, nil)
 	}
-	return resourceCredentialsRead(d, m)
+	return resourceCredentialsRead(ctx, d, m)
 }
 
-func resourceCredentialsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	err := clientInstance.Delete(fmt.Sprintf("/api/v2/credentials/%s/", id))
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/credentials/%s/", id))
 	if err != nil {
-		return fmt.Errorf("failed to delete AWX credentials: %s", err)
+		return diag.Errorf("failed to delete AWX credentials: %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -1,17 +1,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceInventory() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceInventoryCreate,
-		Read:   resourceInventoryRead,
-		Update: resourceInventoryUpdate,
-		Delete: resourceInventoryDelete,
+		CreateContext: resourceInventoryCreate,
+		ReadContext:   resourceInventoryRead,
+		UpdateContext: resourceInventoryUpdate,
+		DeleteContext: resourceInventoryDelete,
+		CustomizeDiff: resourceInventoryCustomizeDiff,
+		Importer:      importByNamedURL("/inventories/"),
 		Description: "Manages an Ansible AWX/Tower inventory. An inventory is a collection of hosts against which jobs " +
 			"may be launched, the same as an Ansible inventory file. Inventories are divided into groups and these " +
 			"groups contain the actual hosts. Groups may be sourced manually, by entering host names into Tower, or " +
@@ -32,18 +36,18 @@ func ResourceInventory() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: StringIsID,
-				Description:  "The organization the inventory belongs to. Inventories must be associated with an organization for role-based access control.",
+				Description:  "The ID of the organization the inventory belongs to, e.g. from an awx_organization resource or data source. Inventories must be associated with an organization for role-based access control.",
 			},
 			"kind": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				ForceNew:    true,
-				Description: "The kind of inventory being represented. Choices include: '' (regular inventory), 'smart' (smart inventory), or 'constructed' (constructed inventory).",
+				Description: "The kind of inventory being represented. Choices include: '' (regular inventory), 'smart' (smart inventory), or 'constructed' (constructed inventory). Use the awx_constructed_inventory resource to manage the inputs of a constructed inventory.",
 			},
 			"host_filter": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Filter that will be applied to the hosts of this inventory. Only used when kind=smart.",
+				Description: "Filter that will be applied to the hosts of this inventory, e.g. 'name__icontains=web and groups__name=prod'. Only allowed when kind=smart; the filter syntax is checked at plan time.",
 			},
 			"variables": {
 				Type:        schema.TypeString,
@@ -56,11 +60,45 @@ func ResourceInventory() *schema.Resource {
 				Default:     false,
 				Description: "If enabled, the inventory will prevent falling back to instance groups defined at the organization or tower level. When disabled, the inventory will use instance groups from the organization or tower level if no inventory-specific instance groups are defined.",
 			},
+			"matched_host_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of hosts matched by the host_filter of a smart inventory. Always 0 for other kinds.",
+			},
+			"matched_hosts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the hosts matched by the host_filter of a smart inventory. Empty for other kinds.",
+			},
 		},
 	}
 }
 
-func resourceInventoryCreate(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("host_filter") || !d.NewValueKnown("kind") {
+		return nil
+	}
+	hostFilter := d.Get("host_filter").(string)
+	if hostFilter == "" {
+		return nil
+	}
+	if kind := d.Get("kind").(string); kind != "smart" {
+		return fmt.Errorf("host_filter can only be set when kind is \"smart\", got kind %q", kind)
+	}
+	if err := validateHostFilter(hostFilter); err != nil {
+		return err
+	}
+	if d.HasChange("host_filter") && d.Id() != "" {
+		if err := d.SetNewComputed("matched_host_count"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("matched_hosts")
+	}
+	return nil
+}
+
+func resourceInventoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"name":                            d.Get("name").(string),
@@ -72,43 +110,58 @@ func resourceInventoryCreate(d *schema.ResourceData, m interface{}) error {
 		"prevent_instance_group_fallback": d.Get("prevent_instance_group_fallback"),
 	}
 
-	resp, err := clientInstance.Post("/api/v2/inventories/", data)
+	resp, err := clientInstance.Post(ctx, "/inventories/", data)
 	if err != nil {
-		return fmt.Errorf("failed to create AWX inventory: %s", err)
+		return diagFromErr("failed to create AWX inventory", err, nil)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceInventoryRead(d, m)
+	return resourceInventoryRead(ctx, d, m)
 }
 
-func resourceInventoryRead(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/inventories/%s/", id))
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/inventories/%s/", id))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX inventory: %s", err)
+		return diag.Errorf("failed to read AWX inventory: %s", err)
+	}
+
+	// Listing the hosts of a large regular inventory is expensive and adds
+	// nothing, so the preview is only read for smart inventories.
+	matchedHosts := []string{}
+	if resp["kind"] == "smart" {
+		hosts, err := clientInstance.GetAll(ctx, fmt.Sprintf("/inventories/%s/hosts/", id))
+		if err != nil {
+			return diag.Errorf("failed to read AWX smart inventory hosts: %s", err)
+		}
+		for _, host := range hosts {
+			matchedHosts = append(matchedHosts, host.(map[string]interface{})["name"].(string))
+		}
 	}
 
 	d.Set("name", resp["name"].(string))
 	d.Set("description", resp["description"].(string))
-	d.Set("organization", resp["organization"])
+	d.Set("organization", NullableF64ToStr(resp["organization"]))
 	d.Set("kind", resp["kind"].(string))
 	d.Set("host_filter", resp["host_filter"])
 	d.Set("variables", resp["variables"].(string))
 	d.Set("prevent_instance_group_fallback", resp["prevent_instance_group_fallback"])
+	d.Set("matched_host_count", len(matchedHosts))
+	d.Set("matched_hosts", matchedHosts)
 	return nil
 }
 
-func resourceInventoryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
@@ -121,20 +174,20 @@ func resourceInventoryUpdate(d *schema.ResourceData, m interface{}) error {
 	data["variables"] = d.Get("variables").(string)
 	data["prevent_instance_group_fallback"] = d.Get("prevent_instance_group_fallback")
 
-	_, err := clientInstance.Put(fmt.Sprintf("/api/v2/inventories/%s/", id), data)
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/inventories/%s/", id), data)
 	if err != nil {
-		return fmt.Errorf("failed to update AWX inventory: %s, %v", err, data)
+		return diagFromErr("failed to update AWX inventory", err, nil)
 	}
-	return resourceInventoryRead(d, m)
+	return resourceInventoryRead(ctx, d, m)
 }
 
-func resourceInventoryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	err := clientInstance.Delete(fmt.Sprintf("/api/v2/inventories/%s/", id))
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/inventories/%s/", id))
 	if err != nil {
-		return fmt.Errorf("failed to delete AWX inventory: %s", err)
+		return diag.Errorf("failed to delete AWX inventory: %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceInventoryGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInventoryGroupCreate,
+		ReadContext:   resourceInventoryGroupRead,
+		UpdateContext: resourceInventoryGroupUpdate,
+		DeleteContext: resourceInventoryGroupDelete,
+		CustomizeDiff: resourceInventoryGroupCustomizeDiff,
+		Importer:      importByNamedURL("/groups/"),
+		Description: "Manages a group within an Ansible AWX/Tower inventory. Groups let playbooks target sets of hosts, " +
+			"e.g. 'hosts: webservers', and carry variables shared by their hosts. Groups can be nested: child groups " +
+			"inherit the variables of their parents, and cycles are rejected before any change is made.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this group. This is the name playbooks use to target the group's hosts.",
+			},
+			"inventory_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the inventory this group belongs to.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Optional description of this group. Can be used to provide more context about the group's purpose.",
+			},
+			"variables": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Group variables in JSON or YAML format. These variables will be available to all hosts in this group and its child groups.",
+			},
+			"children": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "The IDs of the groups nested under this group. Child groups added outside of Terraform are reported as drift and removed.",
+			},
+		},
+	}
+}
+
+// inventoryGroupFields maps AWX group fields to attributes named differently.
+var inventoryGroupFields = map[string]string{
+	"inventory": "inventory_id",
+}
+
+func resourceInventoryGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("children") || !d.NewValueKnown("children") {
+		return nil
+	}
+	o, n := d.GetChange("children")
+	for _, childID := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+		if err := checkInventoryGroupCycle(ctx, m.(*Client), d.Id(), childID.(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkInventoryGroupCycle returns an error when making childID a child of
+// groupID would create a cycle, i.e. when groupID is childID itself or one of
+// its descendants.
+func checkInventoryGroupCycle(ctx context.Context, c *Client, groupID, childID string) error {
+	seen := map[string]bool{}
+	queue := []string{childID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == groupID {
+			return fmt.Errorf("adding group %s as a child of group %s would create a cycle", childID, groupID)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		children, err := c.GetAllIDs(ctx, fmt.Sprintf("/groups/%s/children/", id))
+		if err != nil {
+			return fmt.Errorf("failed to read children of AWX group %s: %s", id, err)
+		}
+		queue = append(queue, children...)
+	}
+	return nil
+}
+
+func resourceInventoryGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	data := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"inventory":   IfaceToInt(d.Get("inventory_id")),
+		"variables":   d.Get("variables").(string),
+	}
+
+	resp, err := clientInstance.Post(ctx, "/groups/", data)
+	if err != nil {
+		return diagFromErr("failed to create AWX inventory group", err, inventoryGroupFields)
+	}
+
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return diag.Errorf("AWX API did not return an id %v", resp)
+	}
+	d.SetId(fmt.Sprintf("%.0f", id))
+
+	if diags := updateInventoryGroupChildren(ctx, d, clientInstance, schema.NewSet(schema.HashString, nil), d.Get("children").(*schema.Set)); diags != nil {
+		return diags
+	}
+	return resourceInventoryGroupRead(ctx, d, m)
+}
+
+func resourceInventoryGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/groups/%s/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX inventory group: %s", err)
+	}
+
+	children, err := clientInstance.GetAllIDs(ctx, fmt.Sprintf("/groups/%s/children/", id))
+	if err != nil {
+		return diag.Errorf("failed to read AWX inventory group children: %s", err)
+	}
+
+	d.Set("name", resp["name"].(string))
+	d.Set("description", resp["description"].(string))
+	d.Set("inventory_id", F64ToStr(resp["inventory"]))
+	d.Set("variables", resp["variables"].(string))
+	d.Set("children", children)
+	return nil
+}
+
+func resourceInventoryGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	data := map[string]interface{}{}
+	data["name"] = d.Get("name").(string)
+	data["description"] = d.Get("description").(string)
+	data["inventory"] = IfaceToInt(d.Get("inventory_id"))
+	data["variables"] = d.Get("variables").(string)
+
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/groups/%s/", id), data)
+	if err != nil {
+		return diagFromErr("failed to update AWX inventory group", err, inventoryGroupFields)
+	}
+
+	if d.HasChange("children") {
+		o, n := d.GetChange("children")
+		if diags := updateInventoryGroupChildren(ctx, d, clientInstance, o.(*schema.Set), n.(*schema.Set)); diags != nil {
+			return diags
+		}
+	}
+	return resourceInventoryGroupRead(ctx, d, m)
+}
+
+func updateInventoryGroupChildren(ctx context.Context, d *schema.ResourceData, c *Client, oldChildren, newChildren *schema.Set) diag.Diagnostics {
+	path := fmt.Sprintf("/groups/%s/children/", d.Id())
+	for _, childID := range oldChildren.Difference(newChildren).List() {
+		err := c.Disassociate(ctx, path, childID.(string))
+		if err != nil && !c.IsNotFound(err) {
+			return diag.Errorf("failed to remove child group %s from AWX inventory group: %s", childID, err)
+		}
+	}
+	for _, childID := range newChildren.Difference(oldChildren).List() {
+		// Children unknown at plan time could not be checked by CustomizeDiff.
+		if err := checkInventoryGroupCycle(ctx, c, d.Id(), childID.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := c.Associate(ctx, path, childID.(string)); err != nil {
+			return diag.Errorf("failed to add child group %s to AWX inventory group: %s", childID, err)
+		}
+	}
+	return nil
+}
+
+func resourceInventoryGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/groups/%s/", id))
+	if err != nil {
+		return diag.Errorf("failed to delete AWX inventory group: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -1,17 +1,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceInventoryHost() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceInventoryHostCreate,
-		Read:   resourceInventoryHostRead,
-		Update: resourceInventoryHostUpdate,
-		Delete: resourceInventoryHostDelete,
+		CreateContext: resourceInventoryHostCreate,
+		ReadContext:   resourceInventoryHostRead,
+		UpdateContext: resourceInventoryHostUpdate,
+		DeleteContext: resourceInventoryHostDelete,
+		CustomizeDiff: resourceInventoryHostCustomizeDiff,
+		Importer:      importByNamedURL("/hosts/"),
 		Description: "Manages a host within an Ansible AWX/Tower inventory. A host represents a managed node that " +
 			"Ansible can configure and manage. Hosts can have variables specific to that host and can be enabled " +
 			"or disabled to control whether they are available for running jobs.",
@@ -49,11 +53,46 @@ func ResourceInventoryHost() *schema.Resource {
 				Optional:    true,
 				Description: "Host variables in JSON or YAML format. These variables will be available to playbooks when running against this specific host and will override inventory variables.",
 			},
+			"group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "The IDs of the inventory groups this host is a direct member of. When set, memberships changed outside of Terraform are reported as drift and reverted, and an empty set removes the host from every group. When omitted, group memberships are not managed.",
+			},
 		},
 	}
 }
 
-func resourceInventoryHostCreate(d *schema.ResourceData, m interface{}) error {
+// inventoryHostFields maps AWX host fields to attributes named differently.
+var inventoryHostFields = map[string]string{
+	"inventory": "inventory_id",
+}
+
+// resourceInventoryHostCustomizeDiff plans the removal of every membership
+// when group_ids is explicitly set to an empty set. The SDK treats an empty
+// Optional+Computed set like an omitted one and keeps the value from state, so
+// the raw configuration is used to tell "[]" (leave every group) apart from
+// null (memberships not managed).
+func resourceInventoryHostCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	groupIDs := config.GetAttr("group_ids")
+	if groupIDs.IsNull() || !groupIDs.IsKnown() || groupIDs.LengthInt() > 0 {
+		return nil
+	}
+	if o, _ := d.GetChange("group_ids"); o.(*schema.Set).Len() == 0 {
+		return nil
+	}
+	return d.SetNew("group_ids", []interface{}{})
+}
+
+func resourceInventoryHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"name":        d.Get("name").(string),
@@ -63,30 +102,39 @@ func resourceInventoryHostCreate(d *schema.ResourceData, m interface{}) error {
 		"variables":   d.Get("variables").(string),
 	}
 
-	resp, err := clientInstance.Post(fmt.Sprintf("/api/v2/inventories/%s/hosts", d.Get("inventory_id").(string)), data)
+	resp, err := clientInstance.Post(ctx, fmt.Sprintf("/inventories/%s/hosts", d.Get("inventory_id").(string)), data)
 	if err != nil {
-		return fmt.Errorf("failed to create AWX inventory host: %s", err)
+		return diagFromErr("failed to create AWX inventory host", err, inventoryHostFields)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceInventoryHostRead(d, m)
+
+	if diags := updateInventoryHostGroups(ctx, d, clientInstance, schema.NewSet(schema.HashString, nil), d.Get("group_ids").(*schema.Set)); diags != nil {
+		return diags
+	}
+	return resourceInventoryHostRead(ctx, d, m)
 }
 
-func resourceInventoryHostRead(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/hosts/%s", id))
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/hosts/%s", id))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX inventory host: %s", err)
+		return diag.Errorf("failed to read AWX inventory host: %s", err)
+	}
+
+	groupIDs, err := clientInstance.GetAllIDs(ctx, fmt.Sprintf("/hosts/%s/groups/", id))
+	if err != nil {
+		return diag.Errorf("failed to read AWX inventory host groups: %s", err)
 	}
 
 	d.Set("name", resp["name"].(string))
@@ -95,10 +143,11 @@ func resourceInventoryHostRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("enabled", resp["enabled"])
 	d.Set("instance_id", resp["instance_id"].(string))
 	d.Set("variables", resp["variables"].(string))
+	d.Set("group_ids", groupIDs)
 	return nil
 }
 
-func resourceInventoryHostUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryHostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
@@ -110,20 +159,43 @@ func resourceInventoryHostUpdate(d *schema.ResourceData, m interface{}) error {
 	data["instance_id"] = d.Get("instance_id").(string)
 	data["variables"] = d.Get("variables").(string)
 
-	_, err := clientInstance.Put(fmt.Sprintf("/api/v2/hosts/%s", id), data)
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/hosts/%s", id), data)
 	if err != nil {
-		return fmt.Errorf("failed to update AWX inventory host: %s, %v", err, data)
+		return diagFromErr("failed to update AWX inventory host", err, inventoryHostFields)
+	}
+
+	if d.HasChange("group_ids") {
+		o, n := d.GetChange("group_ids")
+		if diags := updateInventoryHostGroups(ctx, d, clientInstance, o.(*schema.Set), n.(*schema.Set)); diags != nil {
+			return diags
+		}
 	}
-	return resourceInventoryHostRead(d, m)
+	return resourceInventoryHostRead(ctx, d, m)
+}
+
+func updateInventoryHostGroups(ctx context.Context, d *schema.ResourceData, c *Client, oldGroups, newGroups *schema.Set) diag.Diagnostics {
+	for _, groupID := range oldGroups.Difference(newGroups).List() {
+		err := c.Disassociate(ctx, fmt.Sprintf("/groups/%s/hosts/", groupID), d.Id())
+		if err != nil && !c.IsNotFound(err) {
+			return diag.Errorf("failed to remove AWX inventory host from group %s: %s", groupID, err)
+		}
+	}
+	for _, groupID := range newGroups.Difference(oldGroups).List() {
+		err := c.Associate(ctx, fmt.Sprintf("/groups/%s/hosts/", groupID), d.Id())
+		if err != nil {
+			return diag.Errorf("failed to add AWX inventory host to group %s: %s", groupID, err)
+		}
+	}
+	return nil
 }
 
-func resourceInventoryHostDelete(d *schema.ResourceData, m interface{}) error {
+func resourceInventoryHostDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	err := clientInstance.Delete(fmt.Sprintf("/api/v2/hosts/%s", id))
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/hosts/%s", id))
 	if err != nil {
-		return fmt.Errorf("failed to delete AWX inventory host: %s", err)
+		return diag.Errorf("failed to delete AWX inventory host: %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AWX caps the number of hosts per bulk request, by default at 100 for
+// creation (BULK_HOST_MAX_CREATE) and 250 for deletion (BULK_HOST_MAX_DELETE).
+const (
+	bulkHostCreateBatchSize = 100
+	bulkHostDeleteBatchSize = 250
+)
+
+func ResourceInventoryHosts() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInventoryHostsCreate,
+		ReadContext:   resourceInventoryHostsRead,
+		UpdateContext: resourceInventoryHostsUpdate,
+		DeleteContext: resourceInventoryHostsDelete,
+		CustomizeDiff: resourceInventoryHostsCustomizeDiff,
+		Importer:      importByNamedURL("/inventories/"),
+		Description: "Manages all hosts of an Ansible AWX/Tower inventory as a single resource, using the AWX bulk API. " +
+			"This is much faster than one awx_inventory_host per host for large inventories. The resource is " +
+			"authoritative: hosts of the inventory that are not listed are deleted. Hosts are matched by name, so " +
+			"changing a host's other attributes updates it in place. Creating the resource fails when the inventory " +
+			"already has hosts that are not listed, while listed hosts that already exist are adopted. Destroying the " +
+			"resource only deletes the listed hosts.",
+
+		Schema: map[string]*schema.Schema{
+			"inventory_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the inventory whose hosts are managed.",
+			},
+			"host": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A host of the inventory. Host names must be unique.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of this host. This can be either a DNS name, IP address, or any other name used to identify the host.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Optional description of this host.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "If enabled (true), this host can be used in jobs. If disabled (false), this host will not be used in jobs even if included in the inventory.",
+						},
+						"instance_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The instance ID for this host if it is managed through a cloud provider.",
+						},
+						"variables": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Host variables in JSON or YAML format.",
+						},
+					},
+				},
+			},
+			"host_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of host names to the IDs AWX/Tower assigned to them.",
+			},
+		},
+	}
+}
+
+// inventoryHostsByName indexes the configured or stored hosts by name.
+func inventoryHostsByName(hosts *schema.Set) map[string]map[string]interface{} {
+	byName := make(map[string]map[string]interface{}, hosts.Len())
+	for _, host := range hosts.List() {
+		h := host.(map[string]interface{})
+		byName[h["name"].(string)] = h
+	}
+	return byName
+}
+
+func resourceInventoryHostsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("host") {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, host := range d.Get("host").(*schema.Set).List() {
+		name := host.(map[string]interface{})["name"].(string)
+		if seen[name] {
+			return fmt.Errorf("host %q is declared more than once", name)
+		}
+		seen[name] = true
+	}
+	if d.Id() != "" && d.HasChange("host") {
+		return d.SetNewComputed("host_ids")
+	}
+
+	if d.Id() == "" && d.NewValueKnown("inventory_id") {
+		existing, err := listInventoryHosts(ctx, m.(*Client), d.Get("inventory_id").(string))
+		if err != nil && !m.(*Client).IsNotFound(err) {
+			return fmt.Errorf("failed to read AWX inventory hosts: %s", err)
+		}
+		return undeclaredInventoryHostsError(existing, seen)
+	}
+	return nil
+}
+
+// undeclaredInventoryHostsError returns an error naming the existing hosts
+// that are not declared, which the resource must not silently take over.
+func undeclaredInventoryHostsError(existing []map[string]interface{}, declared map[string]bool) error {
+	var undeclared []string
+	for _, host := range existing {
+		if name := host["name"].(string); !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	if len(undeclared) == 0 {
+		return nil
+	}
+	sort.Strings(undeclared)
+	if len(undeclared) > 10 {
+		undeclared = append(undeclared[:10], fmt.Sprintf("and %d more", len(undeclared)-10))
+	}
+	return fmt.Errorf("the inventory already has hosts that are not declared in host: %s; declare or remove them first", strings.Join(undeclared, ", "))
+}
+
+// listInventoryHosts returns every host of the inventory in the shape of the
+// host attribute, along with its "id".
+func listInventoryHosts(ctx context.Context, c *Client, inventoryID string) ([]map[string]interface{}, error) {
+	hosts, err := c.GetAll(ctx, fmt.Sprintf("/inventories/%s/hosts/?page_size=200", inventoryID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]interface{}, 0, len(hosts))
+	for _, host := range hosts {
+		h := host.(map[string]interface{})
+		result = append(result, map[string]interface{}{
+			"id":          F64ToStr(h["id"]),
+			"name":        h["name"].(string),
+			"description": h["description"].(string),
+			"enabled":     h["enabled"],
+			"instance_id": h["instance_id"].(string),
+			"variables":   h["variables"].(string),
+		})
+	}
+	return result, nil
+}
+
+func resourceInventoryHostsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	inventoryID := d.Get("inventory_id").(string)
+
+	declared := inventoryHostsByName(d.Get("host").(*schema.Set))
+	existing, err := listInventoryHosts(ctx, clientInstance, inventoryID)
+	if err != nil {
+		return diag.Errorf("failed to read AWX inventory hosts: %s", err)
+	}
+	declaredNames := make(map[string]bool, len(declared))
+	for name := range declared {
+		declaredNames[name] = true
+	}
+	if err := undeclaredInventoryHostsError(existing, declaredNames); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Hosts are created in several batches; track the resource from the start
+	// so that hosts of the batches that succeeded are not lost on failure.
+	d.SetId(inventoryID)
+
+	for _, host := range existing {
+		name := host["name"].(string)
+		want := declared[name]
+		delete(declared, name)
+		id := host["id"].(string)
+		delete(host, "id")
+		if reflect.DeepEqual(host, want) {
+			continue
+		}
+		if _, err := clientInstance.Patch(ctx, fmt.Sprintf("/hosts/%s/", id), bulkHostData(want)); err != nil {
+			return append(resourceInventoryHostsRead(ctx, d, m), diagFromErr(fmt.Sprintf("failed to update AWX inventory host %q", name), err, nil)...)
+		}
+	}
+
+	added := make([]interface{}, 0, len(declared))
+	for _, host := range declared {
+		added = append(added, host)
+	}
+	if err := bulkCreateHosts(ctx, clientInstance, inventoryID, added); err != nil {
+		return append(resourceInventoryHostsRead(ctx, d, m), diagFromErr("failed to create AWX inventory hosts", err, nil)...)
+	}
+	return resourceInventoryHostsRead(ctx, d, m)
+}
+
+func resourceInventoryHostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	hosts, err := listInventoryHosts(ctx, clientInstance, id)
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX inventory hosts: %s", err)
+	}
+
+	hostSet := make([]interface{}, 0, len(hosts))
+	hostIDs := make(map[string]interface{}, len(hosts))
+	for _, host := range hosts {
+		hostIDs[host["name"].(string)] = host["id"]
+		delete(host, "id")
+		hostSet = append(hostSet, host)
+	}
+
+	d.Set("inventory_id", id)
+	d.Set("host", hostSet)
+	d.Set("host_ids", hostIDs)
+	return nil
+}
+
+func resourceInventoryHostsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	if d.HasChange("host") {
+		o, n := d.GetChange("host")
+		oldHosts := inventoryHostsByName(o.(*schema.Set))
+		newHosts := inventoryHostsByName(n.(*schema.Set))
+		oldIDs, _ := d.GetChange("host_ids")
+		hostIDs := oldIDs.(map[string]interface{})
+
+		var removed []int
+		for name := range oldHosts {
+			if _, ok := newHosts[name]; !ok && hostIDs[name] != nil {
+				removed = append(removed, IfaceToInt(hostIDs[name]))
+			}
+		}
+		if err := bulkDeleteHosts(ctx, clientInstance, removed); err != nil {
+			return append(resourceInventoryHostsRead(ctx, d, m), diag.Errorf("failed to delete AWX inventory hosts: %s", err)...)
+		}
+
+		var added []interface{}
+		for name, host := range newHosts {
+			oldHost, ok := oldHosts[name]
+			if !ok {
+				added = append(added, host)
+				continue
+			}
+			if reflect.DeepEqual(oldHost, host) || hostIDs[name] == nil {
+				continue
+			}
+			_, err := clientInstance.Patch(ctx, fmt.Sprintf("/hosts/%s/", hostIDs[name].(string)), bulkHostData(host))
+			if err != nil {
+				return append(resourceInventoryHostsRead(ctx, d, m), diagFromErr(fmt.Sprintf("failed to update AWX inventory host %q", name), err, nil)...)
+			}
+		}
+		if err := bulkCreateHosts(ctx, clientInstance, d.Id(), added); err != nil {
+			return append(resourceInventoryHostsRead(ctx, d, m), diagFromErr("failed to create AWX inventory hosts", err, nil)...)
+		}
+	}
+	return resourceInventoryHostsRead(ctx, d, m)
+}
+
+func resourceInventoryHostsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	// Delete the hosts listed in host, looking up their IDs in host_ids.
+	hostIDs := d.Get("host_ids").(map[string]interface{})
+	var ids []int
+	for name := range inventoryHostsByName(d.Get("host").(*schema.Set)) {
+		if id, ok := hostIDs[name]; ok {
+			ids = append(ids, IfaceToInt(id))
+		}
+	}
+	if err := bulkDeleteHosts(ctx, clientInstance, ids); err != nil {
+		return diag.Errorf("failed to delete AWX inventory hosts: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func bulkHostData(host interface{}) map[string]interface{} {
+	h := host.(map[string]interface{})
+	return map[string]interface{}{
+		"name":        h["name"].(string),
+		"description": h["description"].(string),
+		"enabled":     h["enabled"],
+		"instance_id": h["instance_id"].(string),
+		"variables":   h["variables"].(string),
+	}
+}
+
+// bulkCreateHosts creates hosts in an inventory through /bulk/host_create/,
+// in batches AWX accepts.
+func bulkCreateHosts(ctx context.Context, c *Client, inventoryID string, hosts []interface{}) error {
+	for start := 0; start < len(hosts); start += bulkHostCreateBatchSize {
+		end := start + bulkHostCreateBatchSize
+		if end > len(hosts) {
+			end = len(hosts)
+		}
+		batch := make([]map[string]interface{}, 0, end-start)
+		for _, host := range hosts[start:end] {
+			batch = append(batch, bulkHostData(host))
+		}
+		_, err := c.Post(ctx, "/bulk/host_create/", map[string]interface{}{
+			"inventory": IfaceToInt(inventoryID),
+			"hosts":     batch,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkDeleteHosts deletes hosts by ID through /bulk/host_delete/, in batches
+// AWX accepts.
+func bulkDeleteHosts(ctx context.Context, c *Client, ids []int) error {
+	for start := 0; start < len(ids); start += bulkHostDeleteBatchSize {
+		end := start + bulkHostDeleteBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		_, err := c.Post(ctx, "/bulk/host_delete/", map[string]interface{}{
+			"hosts": ids[start:end],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
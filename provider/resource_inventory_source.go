@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceInventorySource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInventorySourceCreate,
+		ReadContext:   resourceInventorySourceRead,
+		UpdateContext: resourceInventorySourceUpdate,
+		DeleteContext: resourceInventorySourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceInventorySourceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Description: "Manages an inventory source of an Ansible AWX/Tower inventory. Inventory sources populate an " +
+			"inventory dynamically from a cloud provider (e.g. AWS EC2 or VMware vCenter) or from an inventory file " +
+			"kept in a project. The source can optionally be synced once when it is created.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this inventory source. Used to identify the source in the AWX/Tower interface.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Optional description of this inventory source.",
+			},
+			"inventory_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the inventory populated by this source.",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of the source. Choices include: 'scm' (sourced from a project), 'ec2', 'gce', 'azure_rm', 'vmware', 'satellite6', 'openstack', 'rhv', 'controller' and 'insights'.",
+			},
+			"source_project_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the project containing the inventory file. Only used when source is 'scm'.",
+			},
+			"source_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the inventory file within source_project_id. Only used when source is 'scm'.",
+			},
+			"credential_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the cloud credential used to authenticate with the source, e.g. an 'Amazon Web Services' or 'VMware vCenter' credential.",
+			},
+			"source_vars": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Inventory plugin configuration in JSON or YAML format, e.g. regions or filters for the 'ec2' source.",
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, hosts and groups no longer present in the source are removed from the inventory on sync.",
+			},
+			"overwrite_vars": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, variables not present in the source are removed from the inventory on sync, instead of being kept.",
+			},
+			"update_on_launch": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, the source is synced before each job run against the inventory.",
+			},
+			"update_cache_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Time in seconds a sync triggered by update_on_launch is considered current, so that jobs launched within that window do not sync again.",
+			},
+			"execution_environment_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the execution environment used to run the inventory sync.",
+			},
+			"sync_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, the source is synced once right after it is created, and creation waits for the sync to finish within the create timeout. Changing it later has no effect on an existing source.",
+			},
+		},
+	}
+}
+
+// inventorySourceFields maps AWX inventory source fields to attributes named differently.
+var inventorySourceFields = map[string]string{
+	"inventory":             "inventory_id",
+	"source_project":        "source_project_id",
+	"credential":            "credential_id",
+	"execution_environment": "execution_environment_id",
+}
+
+func inventorySourceData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"name":                  d.Get("name").(string),
+		"description":           d.Get("description").(string),
+		"inventory":             IfaceToInt(d.Get("inventory_id")),
+		"source":                d.Get("source").(string),
+		"source_path":           d.Get("source_path").(string),
+		"source_vars":           d.Get("source_vars").(string),
+		"overwrite":             d.Get("overwrite"),
+		"overwrite_vars":        d.Get("overwrite_vars"),
+		"update_on_launch":      d.Get("update_on_launch"),
+		"update_cache_timeout":  d.Get("update_cache_timeout"),
+		"source_project":        nil,
+		"credential":            nil,
+		"execution_environment": nil,
+	}
+	for field, attr := range inventorySourceFields {
+		if field != "inventory" && d.Get(attr) != "" {
+			data[field] = IfaceToInt(d.Get(attr))
+		}
+	}
+	return data
+}
+
+func resourceInventorySourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	resp, err := clientInstance.Post(ctx, "/inventory_sources/", inventorySourceData(d))
+	if err != nil {
+		return diagFromErr("failed to create AWX inventory source", err, inventorySourceFields)
+	}
+
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return diag.Errorf("AWX API did not return an id %v", resp)
+	}
+	d.SetId(fmt.Sprintf("%.0f", id))
+
+	if d.Get("sync_on_create").(bool) {
+		if err := syncInventorySource(ctx, clientInstance, d.Id()); err != nil {
+			return diag.Errorf("failed to sync AWX inventory source: %s", err)
+		}
+	}
+	return resourceInventorySourceRead(ctx, d, m)
+}
+
+// syncInventorySource starts an update of the inventory source and waits for
+// it to finish successfully.
+func syncInventorySource(ctx context.Context, c *Client, id string) error {
+	updateID, err := startInventoryUpdate(ctx, c, id)
+	if err != nil {
+		return err
+	}
+	_, err = waitForInventoryUpdate(ctx, c, updateID)
+	return err
+}
+
+// startInventoryUpdate starts an update of the inventory source and returns
+// the ID of the resulting inventory update.
+func startInventoryUpdate(ctx context.Context, c *Client, id string) (string, error) {
+	resp, err := c.Post(ctx, fmt.Sprintf("/inventory_sources/%s/update/", id), nil)
+	if err != nil {
+		return "", err
+	}
+	updateID, ok := resp["inventory_update"].(float64)
+	if !ok {
+		return "", fmt.Errorf("AWX API did not return an inventory update %v", resp)
+	}
+	return fmt.Sprintf("%.0f", updateID), nil
+}
+
+// waitForInventoryUpdate waits for the inventory update to finish and returns
+// its final state, along with an error when it did not succeed.
+func waitForInventoryUpdate(ctx context.Context, c *Client, updateID string) (map[string]interface{}, error) {
+	job, err := waitForJob(ctx, c, fmt.Sprintf("/inventory_updates/%s/", updateID))
+	if err != nil {
+		return nil, err
+	}
+	return job, jobError(ctx, c, job, "")
+}
+
+func resourceInventorySourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/inventory_sources/%s/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX inventory source: %s", err)
+	}
+
+	d.Set("name", resp["name"].(string))
+	d.Set("description", resp["description"].(string))
+	d.Set("inventory_id", F64ToStr(resp["inventory"]))
+	d.Set("source", resp["source"].(string))
+	d.Set("source_project_id", NullableF64ToStr(resp["source_project"]))
+	d.Set("source_path", resp["source_path"].(string))
+	d.Set("credential_id", NullableF64ToStr(resp["credential"]))
+	d.Set("source_vars", resp["source_vars"].(string))
+	d.Set("overwrite", resp["overwrite"])
+	d.Set("overwrite_vars", resp["overwrite_vars"])
+	d.Set("update_on_launch", resp["update_on_launch"])
+	d.Set("update_cache_timeout", resp["update_cache_timeout"])
+	d.Set("execution_environment_id", NullableF64ToStr(resp["execution_environment"]))
+	return nil
+}
+
+func resourceInventorySourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	// sync_on_create only matters when the source is created.
+	if d.HasChangeExcept("sync_on_create") {
+		_, err := clientInstance.Put(ctx, fmt.Sprintf("/inventory_sources/%s/", id), inventorySourceData(d))
+		if err != nil {
+			return diagFromErr("failed to update AWX inventory source", err, inventorySourceFields)
+		}
+	}
+	return resourceInventorySourceRead(ctx, d, m)
+}
+
+// resourceInventorySourceImport resolves the import ID like the other
+// resources and sets sync_on_create to its default, since Read cannot tell
+// how the source was created.
+func resourceInventorySourceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	result, err := importByNamedURL("/inventory_sources/").StateContext(ctx, d, m)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("sync_on_create", false)
+	return result, nil
+}
+
+func resourceInventorySourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/inventory_sources/%s/", id))
+	if err != nil {
+		return diag.Errorf("failed to delete AWX inventory source: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceInventorySourceUpdate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInventorySourceUpdateCreate,
+		ReadContext:   resourceInventorySourceUpdateRead,
+		DeleteContext: resourceInventorySourceUpdateDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Description: "Syncs an Ansible AWX/Tower inventory source and waits for the sync to finish. The apply fails with " +
+			"the sync's traceback or the tail of its output if the sync does not succeed. A new sync is run whenever " +
+			"the triggers change.",
+
+		Schema: map[string]*schema.Schema{
+			"inventory_source_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the inventory source to sync.",
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, run a new sync, e.g. a hash of the source configuration.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the inventory update, e.g. 'successful'.",
+			},
+		},
+	}
+}
+
+func resourceInventorySourceUpdateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	id, err := startInventoryUpdate(ctx, clientInstance, d.Get("inventory_source_id").(string))
+	if err != nil {
+		return diag.Errorf("failed to start AWX inventory update: %s", err)
+	}
+	d.SetId(id)
+
+	job, err := waitForInventoryUpdate(ctx, clientInstance, id)
+	if job != nil {
+		d.Set("status", job["status"])
+	}
+	if err != nil {
+		return diag.Errorf("AWX inventory update failed: %s", err)
+	}
+	return nil
+}
+
+func resourceInventorySourceUpdateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/inventory_updates/%s/", id))
+	if err != nil {
+		// AWX purges old jobs; a missing update must not trigger a new sync.
+		if clientInstance.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("failed to read AWX inventory update: %s", err)
+	}
+
+	d.Set("inventory_source_id", F64ToStr(resp["inventory_source"]))
+	d.Set("status", resp["status"])
+	return nil
+}
+
+func resourceInventorySourceUpdateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
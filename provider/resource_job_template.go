@@ -1,17 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceJobTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceJobTemplateCreate,
-		Read:   resourceJobTemplateRead,
-		Update: resourceJobTemplateUpdate,
-		Delete: resourceJobTemplateDelete,
+		CreateContext: resourceJobTemplateCreate,
+		ReadContext:   resourceJobTemplateRead,
+		UpdateContext: resourceJobTemplateUpdate,
+		DeleteContext: resourceJobTemplateDelete,
+		Importer:      importByNamedURL("/job_templates/"),
 		Description: "Manages an Ansible AWX/Tower job template. A job template is a definition and set of parameters for running " +
 			"an Ansible job. Job templates are useful to execute the same job many times. Job templates can contain specifications " +
 			"for: the inventory to run the job against, the project and playbook to use, credentials, extra variables, and various " +
@@ -94,7 +97,13 @@ func ResourceJobTemplate() *schema.Resource {
 	}
 }
 
-func resourceJobTemplateCreate(d *schema.ResourceData, m interface{}) error {
+// jobTemplateFields maps AWX job template fields to attributes named differently.
+var jobTemplateFields = map[string]string{
+	"inventory": "inventory_id",
+	"project":   "project_id",
+}
+
+func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{}
 	data["name"] = d.Get("name").(string)
@@ -111,37 +120,37 @@ func resourceJobTemplateCreate(d *schema.ResourceData, m interface{}) error {
 	data["inventory"] = IfaceToInt(d.Get("inventory_id"))
 	data["project"] = IfaceToInt(d.Get("project_id"))
 
-	resp, err := clientInstance.Post("/api/v2/job_templates/", data)
+	resp, err := clientInstance.Post(ctx, "/job_templates/", data)
 	if err != nil {
-		return fmt.Errorf("failed to create AWX job template: %s", err)
+		return diagFromErr("failed to create AWX job template", err, jobTemplateFields)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceJobTemplateRead(d, m)
+	return resourceJobTemplateRead(ctx, d, m)
 }
 
-func resourceJobTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/job_templates/%s/", id))
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/job_templates/%s/", id))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX job template: %s", err)
+		return diag.Errorf("failed to read AWX job template: %s", err)
 	}
 
 	d.Set("name", resp["name"].(string))
 	d.Set("description", resp["description"].(string))
 	d.Set("job_type", resp["job_type"].(string))
-	d.Set("inventory_id", resp["inventory"])
-	d.Set("project_id", resp["project"])
+	d.Set("inventory_id", NullableF64ToStr(resp["inventory"]))
+	d.Set("project_id", NullableF64ToStr(resp["project"]))
 	d.Set("playbook", resp["playbook"].(string))
 	d.Set("scm_branch", resp["scm_branch"].(string))
 	d.Set("forks", resp["forks"])
@@ -153,7 +162,7 @@ func resourceJobTemplateRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceJobTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
@@ -172,20 +181,20 @@ func resourceJobTemplateUpdate(d *schema.ResourceData, m interface{}) error {
 	data["job_tags"] = d.Get("job_tags").(string)
 	data["ask_inventory_on_launch"] = d.Get("ask_inventory_on_launch")
 
-	_, err := clientInstance.Put(fmt.Sprintf("/api/v2/job_templates/%s/", id), data)
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/job_templates/%s/", id), data)
 	if err != nil {
-		return fmt.Errorf("failed to update AWX job template: %s, %v", err, data)
+		return diagFromErr("failed to update AWX job template", err, jobTemplateFields)
 	}
-	return resourceJobTemplateRead(d, m)
+	return resourceJobTemplateRead(ctx, d, m)
 }
 
-func resourceJobTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	err := clientInstance.Delete(fmt.Sprintf("/api/v2/job_templates/%s/", id))
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/job_templates/%s/", id))
 	if err != nil {
-		return fmt.Errorf("failed to delete AWX job template: %s", err)
+		return diag.Errorf("failed to delete AWX job template: %s", err)
 	}
 	d.SetId("")
 	return nil
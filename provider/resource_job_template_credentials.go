@@ -1,21 +1,29 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceJobTemplateCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceJobTemplateCredentialCreate,
-		Read:   resourceJobTemplateCredentialRead,
-		Update: resourceJobTemplateCredentialUpdate,
-		Delete: resourceJobTemplateCredentialDelete,
+		CreateContext: resourceJobTemplateCredentialCreate,
+		ReadContext:   resourceJobTemplateCredentialRead,
+		UpdateContext: resourceJobTemplateCredentialUpdate,
+		DeleteContext: resourceJobTemplateCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceJobTemplateCredentialImport,
+		},
 		Description: "Manages credential associations for an Ansible AWX/Tower job template. This resource allows you to " +
 			"associate or disassociate credentials with a job template. Credentials can be used for authentication with " +
-			"various services like SSH, cloud providers, or vault systems when the job template is executed.",
+			"various services like SSH, cloud providers, or vault systems when the job template is executed. Import with " +
+			"'<job_template>_<credential>', where each part is an ID or an AWX named URL. Named URLs may themselves contain " +
+			"underscores; every possible split is then tried, and the import fails unless exactly one of them matches " +
+			"an existing job template and credential.",
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
@@ -32,40 +40,45 @@ func ResourceJobTemplateCredential() *schema.Resource {
 	}
 }
 
-func resourceJobTemplateCredentialCreate(d *schema.ResourceData, m interface{}) error {
+// jobTemplateCredentialFields maps AWX association fields to attributes named differently.
+var jobTemplateCredentialFields = map[string]string{
+	"id": "credentials_id",
+}
+
+func resourceJobTemplateCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"id": IfaceToInt(d.Get("credentials_id")),
 	}
 
-	_, err := clientInstance.Post(fmt.Sprintf("/api/v2/job_templates/%s/credentials", d.Get("job_template_id")), data)
+	_, err := clientInstance.Post(ctx, fmt.Sprintf("/job_templates/%s/credentials", d.Get("job_template_id")), data)
 	if err != nil {
-		return fmt.Errorf("failed to associate credentials with AWX job template: %s", err)
+		return diagFromErr("failed to associate credentials with AWX job template", err, jobTemplateCredentialFields)
 	}
 
 	d.SetId(fmt.Sprintf("%s_%s", d.Get("job_template_id"), d.Get("credentials_id")))
-	return resourceJobTemplateCredentialRead(d, m)
+	return resourceJobTemplateCredentialRead(ctx, d, m)
 }
 
-func resourceJobTemplateCredentialRead(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	ids := strings.Split(d.Id(), "_")
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/job_templates/%s/credentials", d.Get("job_template_id")))
+	results, err := clientInstance.GetAll(ctx, fmt.Sprintf("/job_templates/%s/credentials/", ids[0]))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX job template schedule: %s", err)
+		return diag.Errorf("failed to read AWX job template schedule: %s", err)
 	}
 
 	res := false
-	if len(resp["results"].([]interface{})) == 0 {
+	if len(results) == 0 {
 		d.SetId("")
 		return nil
 	}
-	for _, result := range resp["results"].([]interface{}) {
+	for _, result := range results {
 		if fmt.Sprintf("%0.f", result.(map[string]interface{})["id"]) == ids[1] {
 			res = true
 		}
@@ -74,35 +87,84 @@ func resourceJobTemplateCredentialRead(d *schema.ResourceData, m interface{}) er
 		d.SetId("")
 		return nil
 	}
+	d.Set("job_template_id", ids[0])
+	d.Set("credentials_id", ids[1])
 	return nil
 }
 
-func resourceJobTemplateCredentialUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateCredentialUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"id": IfaceToInt(d.Get("credentials_id")),
 	}
 
-	_, err := clientInstance.Post(fmt.Sprintf("/api/v2/job_templates/%s/credentials", d.Get("job_template_id")), data)
+	_, err := clientInstance.Post(ctx, fmt.Sprintf("/job_templates/%s/credentials", d.Get("job_template_id")), data)
 	if err != nil {
-		return fmt.Errorf("failed to associate credentials with AWX job template: %s", err)
+		return diagFromErr("failed to associate credentials with AWX job template", err, jobTemplateCredentialFields)
 	}
 
 	d.SetId(fmt.Sprintf("%s_%s", d.Get("job_template_id"), d.Get("credentials_id")))
-	return resourceJobTemplateCredentialRead(d, m)
+	return resourceJobTemplateCredentialRead(ctx, d, m)
 }
 
-func resourceJobTemplateCredentialDelete(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"id":           IfaceToInt(d.Get("credentials_id")),
 		"disassociate": true,
 	}
 
-	_, err := clientInstance.Post(fmt.Sprintf("/api/v2/job_templates/%s/credentials", d.Get("job_template_id")), data)
+	_, err := clientInstance.Post(ctx, fmt.Sprintf("/job_templates/%s/credentials", d.Get("job_template_id")), data)
 	if err != nil {
-		return fmt.Errorf("failed to dicassociate credentials with AWX job template: %s", err)
+		return diag.Errorf("failed to dicassociate credentials with AWX job template: %s", err)
 	}
 	d.SetId("")
 	return nil
 }
+
+// resourceJobTemplateCredentialImport accepts "<job_template>_<credential>".
+// Since named URLs may contain underscores, the ID is split at every
+// underscore in turn and the import succeeds only if exactly one split
+// resolves to an existing job template and credential.
+func resourceJobTemplateCredentialImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clientInstance := m.(*Client)
+	ref := d.Id()
+
+	var matches []string
+	for i := strings.Index(ref, "_"); i >= 0; i = nextIndex(ref, "_", i) {
+		if i == 0 || i == len(ref)-1 {
+			continue
+		}
+		jobTemplateID, err := resolveID(ctx, clientInstance, "/job_templates/", ref[:i])
+		if err == nil {
+			var credentialID string
+			credentialID, err = resolveID(ctx, clientInstance, "/credentials/", ref[i+1:])
+			if err == nil {
+				matches = append(matches, fmt.Sprintf("%s_%s", jobTemplateID, credentialID))
+				continue
+			}
+		}
+		if !clientInstance.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to resolve import ID %q: %s", ref, err)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unexpected import ID %q, expected <job_template>_<credential> matching an existing job template and credential", ref)
+	case 1:
+		d.SetId(matches[0])
+		return []*schema.ResourceData{d}, nil
+	default:
+		return nil, fmt.Errorf("ambiguous import ID %q matches several job template and credential pairs (%s); use numeric IDs", ref, strings.Join(matches, ", "))
+	}
+}
+
+// nextIndex returns the index of the next occurrence of sep in s after i, or -1.
+func nextIndex(s, sep string, i int) int {
+	j := strings.Index(s[i+1:], sep)
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
@@ -1,71 +1,553 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceJobTemplateLaunch() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceJobTemplateLaunchCreateOrUpdate,
-		Read:   resourceJobTemplateLaunchRead,
-		Update: resourceJobTemplateLaunchCreateOrUpdate,
-		Delete: resourceJobTemplateLaunchDelete,
+		CreateContext: resourceJobTemplateLaunchCreate,
+		ReadContext:   resourceJobTemplateLaunchRead,
+		UpdateContext: resourceJobTemplateLaunchUpdate,
+		DeleteContext: resourceJobTemplateLaunchDelete,
+		CustomizeDiff: resourceJobTemplateLaunchCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Description: "Launches an Ansible AWX/Tower job template. This resource allows you to execute job templates and " +
-			"optionally override certain parameters such as inventory and variables. The job will be launched when this " +
-			"resource is created or updated.",
+			"optionally override the parameters the job template prompts for on launch. Overrides the job template does " +
+			"not prompt for are rejected at plan time, as AWX/Tower would silently ignore them. The job is launched when this " +
+			"resource is created, and launched again whenever the job template, an override or the triggers change. With " +
+			"wait_for_completion, the apply waits for the job to finish and fails when the job does not succeed.",
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
 				Type:         schema.TypeString,
 				Required:     true,
+				ForceNew:     true,
 				ValidateFunc: StringIsID,
 				Description:  "The ID of the job template to launch. This is a required field that specifies which AWX/Tower job template should be executed.",
 			},
 			"inventory_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				ForceNew:     true,
 				ValidateFunc: StringIsID,
-				Description:  "The ID of the inventory to use for this job launch. If specified, this will override the inventory set in the job template.",
+				Description:  "The ID of the inventory to use for this job launch. If specified, this will override the inventory set in the job template, which must prompt for the inventory on launch.",
 			},
 			"extra_vars": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "A JSON or YAML string containing extra variables to pass to the job template. These variables will be merged with any survey variables defined in the job template.",
+				ForceNew:    true,
+				Description: "A JSON or YAML string containing extra variables to pass to the job template. These variables will be merged with any survey variables defined in the job template. Variables other than survey answers require the job template to prompt for variables on launch. Required survey answers are checked at plan time when extra_vars is JSON.",
+			},
+			"limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Host pattern further restricting the hosts the job runs against. The job template must prompt for the limit on launch.",
+			},
+			"job_tags": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Comma separated list of tags to run. The job template must prompt for tags on launch.",
+			},
+			"skip_tags": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Comma separated list of tags to skip. The job template must prompt for skip tags on launch.",
+			},
+			"job_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"run", "check"}, false),
+				Description:  "Job type to launch, either 'run' or 'check'. The job template must prompt for the job type on launch.",
+			},
+			"verbosity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 5),
+				Description:  "Verbosity of the job output (0-5). The job template must prompt for verbosity on launch.",
+			},
+			"diff_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether to show the changes made by tasks that support diff mode. The job template must prompt for diff mode on launch.",
+			},
+			"credential_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "The IDs of the credentials to use instead of those of the job template. The job template must prompt for credentials on launch.",
+			},
+			"scm_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Branch, tag or commit of the project to run. The job template must prompt for the SCM branch on launch.",
+			},
+			"forks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of parallel processes used by the job. The job template must prompt for forks on launch.",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds the job may run before it is canceled, 0 for no limit. The job template must prompt for the timeout on launch.",
+			},
+			"execution_environment_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the execution environment to run the job in. The job template must prompt for the execution environment on launch.",
+			},
+			"label_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "The IDs of the labels to attach to the job. The job template must prompt for labels on launch.",
+			},
+			"instance_group_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "Ordered list of the IDs of the instance groups to run the job on. The job template must prompt for instance groups on launch.",
+			},
+			"job_slice_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of slices to divide the job into. The job template must prompt for the job slice count on launch.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, launch the job again, e.g. a hash of the files the playbook deploys.",
+			},
+			"relaunch_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When the job fails, relaunch it once against the hosts that failed and use the result of the relaunch. Requires wait_for_completion.",
+			},
+			"cancel_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Cancel the job when this resource is destroyed while the job is still running, and wait for it to be canceled within the delete timeout. Otherwise destroying only removes the job from the state.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the launched job to reach a terminal status, failing the apply when it ends as failed, error or canceled. The wait is bounded by the create timeout.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the launched job, e.g. pending, running, successful or failed.",
+			},
+			"started": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the job started running, empty if it has not started.",
+			},
+			"finished": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the job finished, empty if it has not finished.",
+			},
+			"elapsed": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Seconds the job ran for.",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the job failed.",
+			},
+			"stdout_tail_lines": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of trailing lines of the job output captured in stdout once the job has finished. Set to 0 to not capture output.",
+			},
+			"artifacts": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Artifacts the playbook recorded with set_stats, once the job has finished. Values that are not strings are JSON encoded.",
+			},
+			"artifacts_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Artifacts the playbook recorded with set_stats as a JSON object, for use with jsondecode() when values are nested.",
+			},
+			"stdout": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last stdout_tail_lines lines of the job output, once the job has finished.",
 			},
 		},
 	}
 }
 
-func resourceJobTemplateLaunchCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
-	clientInstance := m.(*Client)
+// jobTemplateLaunchFields maps AWX launch fields to attributes named differently.
+var jobTemplateLaunchFields = map[string]string{
+	"inventory":             "inventory_id",
+	"credentials":           "credential_ids",
+	"execution_environment": "execution_environment_id",
+	"labels":                "label_ids",
+	"instance_groups":       "instance_group_ids",
+}
+
+// jobTemplateLaunchPrompt describes a value that can be supplied when
+// launching a job template, and the job template flag that makes AWX accept it.
+type jobTemplateLaunchPrompt struct {
+	attribute string
+	field     string
+	askFlag   string
+	isID      bool
+}
 
+var jobTemplateLaunchPrompts = []jobTemplateLaunchPrompt{
+	{attribute: "inventory_id", field: "inventory", askFlag: "ask_inventory_on_launch", isID: true},
+	{attribute: "extra_vars", field: "extra_vars", askFlag: "ask_variables_on_launch"},
+	{attribute: "limit", field: "limit", askFlag: "ask_limit_on_launch"},
+	{attribute: "job_tags", field: "job_tags", askFlag: "ask_tags_on_launch"},
+	{attribute: "skip_tags", field: "skip_tags", askFlag: "ask_skip_tags_on_launch"},
+	{attribute: "job_type", field: "job_type", askFlag: "ask_job_type_on_launch"},
+	{attribute: "verbosity", field: "verbosity", askFlag: "ask_verbosity_on_launch"},
+	{attribute: "diff_mode", field: "diff_mode", askFlag: "ask_diff_mode_on_launch"},
+	{attribute: "credential_ids", field: "credentials", askFlag: "ask_credential_on_launch", isID: true},
+	{attribute: "scm_branch", field: "scm_branch", askFlag: "ask_scm_branch_on_launch"},
+	{attribute: "forks", field: "forks", askFlag: "ask_forks_on_launch"},
+	{attribute: "timeout", field: "timeout", askFlag: "ask_timeout_on_launch"},
+	{attribute: "execution_environment_id", field: "execution_environment", askFlag: "ask_execution_environment_on_launch", isID: true},
+	{attribute: "label_ids", field: "labels", askFlag: "ask_labels_on_launch", isID: true},
+	{attribute: "instance_group_ids", field: "instance_groups", askFlag: "ask_instance_groups_on_launch", isID: true},
+	{attribute: "job_slice_count", field: "job_slice_count", askFlag: "ask_job_slice_count_on_launch"},
+}
+
+// launchPromptConfigured reports whether attribute is set in the resource
+// configuration. The raw configuration is used because false and 0 are
+// meaningful prompt values that d.GetOk cannot tell apart from unset ones.
+// Values that are not known yet count as set.
+func launchPromptConfigured(config cty.Value, attribute string) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return !config.GetAttr(attribute).IsNull()
+}
+
+func jobTemplateLaunchData(d *schema.ResourceData) map[string]interface{} {
 	data := map[string]interface{}{}
-	data["job_template_id"] = IfaceToInt(d.Get("job_template_id"))
-	data["extra_vars"] = d.Get("extra_vars").(string)
-	if d.Get("inventory_id") != "" {
-		data["inventory_id"] = IfaceToInt(d.Get("inventory_id"))
+	config := d.GetRawConfig()
+	for _, prompt := range jobTemplateLaunchPrompts {
+		if !launchPromptConfigured(config, prompt.attribute) {
+			continue
+		}
+		value := d.Get(prompt.attribute)
+		if set, ok := value.(*schema.Set); ok {
+			value = set.List()
+		}
+		if prompt.isID {
+			switch v := value.(type) {
+			case []interface{}:
+				ids := make([]int, 0, len(v))
+				for _, id := range v {
+					ids = append(ids, IfaceToInt(id))
+				}
+				value = ids
+			default:
+				value = IfaceToInt(v)
+			}
+		}
+		data[prompt.field] = value
+	}
+	return data
+}
+
+func resourceJobTemplateLaunchCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("relaunch_on_failure").(bool) && !d.Get("wait_for_completion").(bool) {
+		return errors.New("relaunch_on_failure requires wait_for_completion")
+	}
+	if d.Id() != "" && d.HasChange("stdout_tail_lines") {
+		if err := d.SetNewComputed("stdout"); err != nil {
+			return err
+		}
+	}
+
+	changed := d.Id() == "" || d.HasChanges("job_template_id", "triggers")
+	for _, prompt := range jobTemplateLaunchPrompts {
+		changed = changed || d.HasChange(prompt.attribute)
+	}
+	if !changed || !d.NewValueKnown("job_template_id") {
+		return nil
+	}
+
+	clientInstance := m.(*Client)
+	launch, err := clientInstance.Get(ctx, fmt.Sprintf("/job_templates/%s/launch/", d.Get("job_template_id")))
+	if err != nil {
+		return fmt.Errorf("failed to read launch requirements of AWX job template %s: %s", d.Get("job_template_id"), err)
+	}
+	return checkJobTemplateLaunchPrompts(d, launch)
+}
+
+// checkJobTemplateLaunchPrompts checks the configured prompts against the
+// launch requirements AWX returns from GET /job_templates/{id}/launch/.
+func checkJobTemplateLaunchPrompts(d *schema.ResourceDiff, launch map[string]interface{}) error {
+	config := d.GetRawConfig()
+	surveyEnabled, _ := launch["survey_enabled"].(bool)
+
+	var errs []error
+	for _, prompt := range jobTemplateLaunchPrompts {
+		if !launchPromptConfigured(config, prompt.attribute) {
+			continue
+		}
+		// Survey answers are passed as extra_vars even when the job template
+		// does not prompt for variables.
+		if ask, _ := launch[prompt.askFlag].(bool); ask || (prompt.attribute == "extra_vars" && surveyEnabled) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s cannot be set: the job template does not prompt for it on launch (%s is false)", prompt.attribute, prompt.askFlag))
+	}
+
+	if needed, _ := launch["inventory_needed_to_start"].(bool); needed && !launchPromptConfigured(config, "inventory_id") {
+		errs = append(errs, errors.New("inventory_id is required: the job template has no inventory"))
+	}
+	if needed, _ := launch["credential_needed_to_start"].(bool); needed && !launchPromptConfigured(config, "credential_ids") {
+		errs = append(errs, errors.New("credential_ids is required: the job template has no credentials"))
 	}
 
-	resp, err := clientInstance.Post(fmt.Sprintf("/api/v2/job_templates/%s/launch", d.Get("job_template_id")), data)
+	if needed, _ := launch["variables_needed_to_start"].([]interface{}); len(needed) > 0 && d.NewValueKnown("extra_vars") {
+		// YAML extra_vars are left for AWX to check.
+		extraVars := map[string]interface{}{}
+		if raw := d.Get("extra_vars").(string); raw == "" || json.Unmarshal([]byte(raw), &extraVars) == nil {
+			var missing []string
+			for _, name := range needed {
+				if _, ok := extraVars[name.(string)]; !ok {
+					missing = append(missing, name.(string))
+				}
+			}
+			if len(missing) > 0 {
+				sort.Strings(missing)
+				errs = append(errs, fmt.Errorf("extra_vars is missing survey answers required to launch the job template: %s", strings.Join(missing, ", ")))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	resp, err := clientInstance.Post(ctx, fmt.Sprintf("/job_templates/%s/launch/", d.Get("job_template_id")), jobTemplateLaunchData(d))
 	if err != nil {
-		return fmt.Errorf("failed to create AWX job template launch: %s", err)
+		return diagFromErr("failed to create AWX job template launch", err, jobTemplateLaunchFields)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceJobTemplateLaunchRead(d, m)
+
+	job := resp
+	if d.Get("wait_for_completion").(bool) {
+		job, err = waitForJob(ctx, clientInstance, fmt.Sprintf("/jobs/%s/", d.Id()))
+		if err != nil {
+			return diag.Errorf("failed to wait for AWX job: %s", err)
+		}
+
+		if job["status"] == "failed" && d.Get("relaunch_on_failure").(bool) {
+			resp, err := clientInstance.Post(ctx, fmt.Sprintf("/jobs/%s/relaunch/", d.Id()), map[string]interface{}{
+				"hosts": "failed",
+			})
+			if err != nil {
+				return diag.Errorf("failed to relaunch AWX job %s: %s", d.Id(), err)
+			}
+			id, ok := resp["id"].(float64)
+			if !ok {
+				return diag.Errorf("AWX API did not return an id %v", resp)
+			}
+			d.SetId(fmt.Sprintf("%.0f", id))
+
+			job, err = waitForJob(ctx, clientInstance, fmt.Sprintf("/jobs/%s/", d.Id()))
+			if err != nil {
+				return diag.Errorf("failed to wait for AWX job: %s", err)
+			}
+		}
+	}
+	if diags := setJobTemplateLaunchJob(ctx, d, clientInstance, job); diags != nil {
+		return diags
+	}
+	if d.Get("wait_for_completion").(bool) {
+		// Reuse the output captured above rather than downloading it again.
+		if err := jobError(ctx, clientInstance, job, d.Get("stdout").(string)); err != nil {
+			return diag.Errorf("AWX job failed: %s", err)
+		}
+	}
+	return nil
 }
 
-func resourceJobTemplateLaunchRead(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	job, err := clientInstance.Get(ctx, fmt.Sprintf("/jobs/%s/", id))
+	if err != nil {
+		// AWX purges old jobs; a missing job must not trigger a new launch.
+		if clientInstance.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("failed to read AWX job: %s", err)
+	}
+	return setJobTemplateLaunchJob(ctx, d, clientInstance, job)
+}
+
+// resourceJobTemplateLaunchUpdate only handles attributes that do not launch
+// the job again; every launch input forces a new resource.
+func resourceJobTemplateLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceJobTemplateLaunchRead(ctx, d, m)
+}
+
+// setJobTemplateLaunchJob sets the computed job attributes from an AWX job.
+// Artifacts and output are only captured once the job has finished. The output
+// does not change after that, so it is downloaded once, when the job is first
+// seen finished or stdout_tail_lines changes, and kept from state afterwards.
+func setJobTemplateLaunchJob(ctx context.Context, d *schema.ResourceData, c *Client, job map[string]interface{}) diag.Diagnostics {
+	captureStdout := !jobFinished(d.Get("status").(string)) || d.HasChange("stdout_tail_lines")
+
+	status, _ := job["status"].(string)
+	started, _ := job["started"].(string)
+	finished, _ := job["finished"].(string)
+	d.Set("status", status)
+	d.Set("started", started)
+	d.Set("finished", finished)
+	d.Set("elapsed", job["elapsed"])
+	d.Set("failed", job["failed"])
+
+	artifacts := map[string]interface{}{}
+	artifactsJSON := ""
+	stdout := d.Get("stdout").(string)
+	if !jobFinished(status) {
+		stdout = ""
+	} else {
+		raw, _ := job["artifacts"].(map[string]interface{})
+		for key, value := range raw {
+			if str, ok := value.(string); ok {
+				artifacts[key] = str
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return diag.Errorf("failed to encode AWX job artifact %q: %s", key, err)
+			}
+			artifacts[key] = string(encoded)
+		}
+		if raw != nil {
+			encoded, err := json.Marshal(raw)
+			if err != nil {
+				return diag.Errorf("failed to encode AWX job artifacts: %s", err)
+			}
+			artifactsJSON = string(encoded)
+		}
+
+		if captureStdout {
+			stdout = ""
+			if lines := d.Get("stdout_tail_lines").(int); lines > 0 {
+				output, err := jobStdout(ctx, c, job)
+				if err != nil {
+					return diag.Errorf("failed to read AWX job output: %s", err)
+				}
+				stdout = tailLines(output, lines)
+			}
+		}
+	}
+	d.Set("artifacts", artifacts)
+	d.Set("artifacts_json", artifactsJSON)
+	d.Set("stdout", stdout)
 	return nil
 }
 
-func resourceJobTemplateLaunchDelete(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("cancel_on_destroy").(bool) {
+		if diags := cancelJobTemplateLaunchJob(ctx, d, m.(*Client)); diags != nil {
+			return diags
+		}
+	}
 	d.SetId("")
 	return nil
 }
+
+// cancelJobTemplateLaunchJob cancels the launched job if AWX reports it can
+// still be canceled, and waits for it to stop.
+func cancelJobTemplateLaunchJob(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	path := fmt.Sprintf("/jobs/%s/cancel/", d.Id())
+
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		if c.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("failed to read AWX job cancel status: %s", err)
+	}
+	if canCancel, _ := resp["can_cancel"].(bool); !canCancel {
+		return nil
+	}
+
+	if _, err := c.Post(ctx, path, map[string]interface{}{}); err != nil {
+		// The job may have finished between the check and the request.
+		if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode == http.StatusMethodNotAllowed {
+			return nil
+		}
+		return diag.Errorf("failed to cancel AWX job %s: %s", d.Id(), err)
+	}
+
+	if _, err := waitForJob(ctx, c, fmt.Sprintf("/jobs/%s/", d.Id())); err != nil {
+		return diag.Errorf("failed to wait for AWX job to be canceled: %s", err)
+	}
+	return nil
+}
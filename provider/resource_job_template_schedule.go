@@ -1,17 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceJobTemplateSchedule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceJobTemplateScheduleCreate,
-		Read:   resourceJobTemplateScheduleRead,
-		Update: resourceJobTemplateScheduleUpdate,
-		Delete: resourceJobTemplateScheduleDelete,
+		CreateContext: resourceJobTemplateScheduleCreate,
+		ReadContext:   resourceJobTemplateScheduleRead,
+		UpdateContext: resourceJobTemplateScheduleUpdate,
+		DeleteContext: resourceJobTemplateScheduleDelete,
+		Importer:      importByNamedURL("/schedules/"),
 		Description: "Manages a schedule for an Ansible AWX/Tower job template. This resource allows you to create, " +
 			"update, and delete scheduled runs of job templates. You can configure various parameters including the " +
 			"execution schedule (using RRULE format), playbook options, and variables.",
@@ -95,7 +98,14 @@ func ResourceJobTemplateSchedule() *schema.Resource {
 	}
 }
 
-func resourceJobTemplateScheduleCreate(d *schema.ResourceData, m interface{}) error {
+// jobTemplateScheduleFields maps AWX schedule fields to attributes named differently.
+var jobTemplateScheduleFields = map[string]string{
+	"unified_job_template": "job_template_id",
+	"inventory":            "inventory_id",
+	"project":              "project_id",
+}
+
+func resourceJobTemplateScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"name":        d.Get("name").(string),
@@ -114,30 +124,30 @@ func resourceJobTemplateScheduleCreate(d *schema.ResourceData, m interface{}) er
 		data["inventory"] = IfaceToInt(d.Get("inventory_id"))
 	}
 
-	resp, err := clientInstance.Post(fmt.Sprintf("/api/v2/job_templates/%s/schedules", d.Get("job_template_id")), data)
+	resp, err := clientInstance.Post(ctx, fmt.Sprintf("/job_templates/%s/schedules", d.Get("job_template_id")), data)
 	if err != nil {
-		return fmt.Errorf("failed to create AWX job template schedule: %s", err)
+		return diagFromErr("failed to create AWX job template schedule", err, jobTemplateScheduleFields)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceJobTemplateScheduleRead(d, m)
+	return resourceJobTemplateScheduleRead(ctx, d, m)
 }
 
-func resourceJobTemplateScheduleRead(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/schedules/%s/", id))
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/schedules/%s/", id))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX job template schedule: %s", err)
+		return diag.Errorf("failed to read AWX job template schedule: %s", err)
 	}
 
 	d.Set("name", resp["name"].(string))
@@ -151,13 +161,14 @@ func resourceJobTemplateScheduleRead(d *schema.ResourceData, m interface{}) erro
 	d.Set("extra_vars", resp["extra_vars"])
 	d.Set("job_tags", resp["job_tags"].(string))
 	d.Set("rrule", resp["rrule"].(string))
+	d.Set("job_template_id", F64ToStr(resp["unified_job_template"]))
 	if resp["inventory"] != nil {
 		d.Set("inventory_id", F64ToStr(resp["inventory"]))
 	}
 	return nil
 }
 
-func resourceJobTemplateScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
@@ -179,20 +190,20 @@ func resourceJobTemplateScheduleUpdate(d *schema.ResourceData, m interface{}) er
 		data["inventory"] = IfaceToInt(d.Get("inventory_id"))
 	}
 
-	_, err := clientInstance.Put(fmt.Sprintf("/api/v2/schedules/%s/", id), data)
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/schedules/%s/", id), data)
 	if err != nil {
-		return fmt.Errorf("failed to update AWX job template schedule: %s, %v", err, data)
+		return diagFromErr("failed to update AWX job template schedule", err, jobTemplateScheduleFields)
 	}
-	return resourceJobTemplateScheduleRead(d, m)
+	return resourceJobTemplateScheduleRead(ctx, d, m)
 }
 
-func resourceJobTemplateScheduleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceJobTemplateScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	err := clientInstance.Delete(fmt.Sprintf("/api/v2/schedules/%s/", id))
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/schedules/%s/", id))
 	if err != nil {
-		return fmt.Errorf("failed to delete AWX job template schedule: %s", err)
+		return diag.Errorf("failed to delete AWX job template schedule: %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationCreate,
+		ReadContext:   resourceOrganizationRead,
+		UpdateContext: resourceOrganizationUpdate,
+		DeleteContext: resourceOrganizationDelete,
+		Importer:      importByNamedURL("/organizations/"),
+		Description: "Manages an Ansible AWX/Tower organization. An organization is a logical collection of users, teams, " +
+			"projects, inventories and credentials, and is the top level in the AWX/Tower object hierarchy. Most objects " +
+			"must belong to an organization for role-based access control.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this organization. Used to identify the organization in the AWX/Tower interface.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Optional description of this organization. Can be used to provide more context about the organization's purpose.",
+			},
+			"max_hosts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of hosts allowed to be managed by this organization. Default of 0 means no limit.",
+			},
+			"default_environment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the execution environment used by default for jobs run by this organization's resources.",
+			},
+			"galaxy_credentials": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "Ordered list of Ansible Galaxy credential IDs used to download content collections and roles. Credentials are tried in the given order.",
+			},
+		},
+	}
+}
+
+func organizationData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"name":                d.Get("name").(string),
+		"description":         d.Get("description").(string),
+		"max_hosts":           d.Get("max_hosts"),
+		"default_environment": nil,
+	}
+	if d.Get("default_environment") != "" {
+		data["default_environment"] = IfaceToInt(d.Get("default_environment"))
+	}
+	return data
+}
+
+func resourceOrganizationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+
+	resp, err := clientInstance.Post(ctx, "/organizations/", organizationData(d))
+	if err != nil {
+		return diagFromErr("failed to create AWX organization", err, nil)
+	}
+
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return diag.Errorf("AWX API did not return an id %v", resp)
+	}
+	d.SetId(fmt.Sprintf("%.0f", id))
+
+	for _, credentialID := range d.Get("galaxy_credentials").([]interface{}) {
+		err := clientInstance.Associate(ctx, fmt.Sprintf("/organizations/%s/galaxy_credentials/", d.Id()), credentialID.(string))
+		if err != nil {
+			return diagFromErr("failed to associate galaxy credentials with AWX organization", err, nil)
+		}
+	}
+	return resourceOrganizationRead(ctx, d, m)
+}
+
+func resourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/organizations/%s/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX organization: %s", err)
+	}
+
+	galaxyCredentials, err := clientInstance.GetAllIDs(ctx, fmt.Sprintf("/organizations/%s/galaxy_credentials/", id))
+	if err != nil {
+		return diag.Errorf("failed to read AWX organization galaxy credentials: %s", err)
+	}
+
+	d.Set("name", resp["name"].(string))
+	d.Set("description", resp["description"].(string))
+	d.Set("max_hosts", resp["max_hosts"])
+	d.Set("default_environment", NullableF64ToStr(resp["default_environment"]))
+	d.Set("galaxy_credentials", galaxyCredentials)
+	return nil
+}
+
+func resourceOrganizationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/organizations/%s/", id), organizationData(d))
+	if err != nil {
+		return diagFromErr("failed to update AWX organization", err, nil)
+	}
+
+	if d.HasChange("galaxy_credentials") {
+		// Galaxy credentials are ordered and AWX appends on association, so
+		// re-associate the whole list to apply the configured order.
+		path := fmt.Sprintf("/organizations/%s/galaxy_credentials/", id)
+		old, _ := d.GetChange("galaxy_credentials")
+		for _, credentialID := range old.([]interface{}) {
+			if err := clientInstance.Disassociate(ctx, path, credentialID.(string)); err != nil && !clientInstance.IsNotFound(err) {
+				return diagFromErr("failed to disassociate galaxy credentials from AWX organization", err, nil)
+			}
+		}
+		for _, credentialID := range d.Get("galaxy_credentials").([]interface{}) {
+			if err := clientInstance.Associate(ctx, path, credentialID.(string)); err != nil {
+				return diagFromErr("failed to associate galaxy credentials with AWX organization", err, nil)
+			}
+		}
+	}
+	return resourceOrganizationRead(ctx, d, m)
+}
+
+func resourceOrganizationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/organizations/%s/", id))
+	if err != nil {
+		return diag.Errorf("failed to delete AWX organization: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
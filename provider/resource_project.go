@@ -1,18 +1,28 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Read:   resourceProjectRead,
-		Update: resourceProjectUpdate,
-		Delete: resourceProjectDelete,
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
+		Importer:      importByNamedURL("/projects/"),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Description: "Manages an Ansible AWX/Tower project. A project is a logical collection of Ansible playbooks, " +
 			"represented in Tower. You can manage playbooks and playbook directories by either placing them manually " +
 			"under the Project Base Path on your Tower server, or by placing your playbooks into a source code " +
@@ -32,7 +42,7 @@ func ResourceProject() *schema.Resource {
 			"organization": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "The organization the project belongs to. Projects must be associated with an organization for role-based access control.",
+				Description: "The ID of the organization the project belongs to, e.g. from an awx_organization resource or data source. Projects must be associated with an organization for role-based access control.",
 			},
 			"local_path": {
 				Type:        schema.TypeString,
@@ -101,7 +111,12 @@ func ResourceProject() *schema.Resource {
 	}
 }
 
-func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
+// projectFields maps AWX project fields to attributes named differently.
+var projectFields = map[string]string{
+	"credential": "credential_id",
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	data := map[string]interface{}{
 		"name":                 d.Get("name").(string),
@@ -122,30 +137,30 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 		credential_id, _ := strconv.Atoi(d.Get("credential_id").(string))
 		data["credential_id"] = credential_id
 	}
-	resp, err := clientInstance.Post("/api/v2/projects/", data)
+	resp, err := clientInstance.Post(ctx, "/projects/", data)
 	if err != nil {
-		return fmt.Errorf("failed to create AWX project: %s", err)
+		return diagFromErr("failed to create AWX project", err, projectFields)
 	}
 
 	id, ok := resp["id"].(float64)
 	if !ok {
-		return fmt.Errorf("AWX API did not return an id %v", resp)
+		return diag.Errorf("AWX API did not return an id %v", resp)
 	}
 	d.SetId(fmt.Sprintf("%.0f", id))
-	return resourceProjectRead(d, m)
+	return resourceProjectRead(ctx, d, m)
 }
 
-func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	resp, err := clientInstance.Get(fmt.Sprintf("/api/v2/projects/%s/", id))
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/projects/%s/", id))
 	if err != nil {
 		if clientInstance.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("failed to read AWX project: %s", err)
+		return diag.Errorf("failed to read AWX project: %s", err)
 	}
 
 	d.Set("name", resp["name"].(string))
@@ -158,13 +173,13 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("scm_clean", resp["scm_clean"])
 	d.Set("scm_track_submodules", resp["scm_track_submodules"])
 	d.Set("scm_delete_on_update", resp["scm_delete_on_update"])
-	d.Set("credential_id", resp["credential"])
+	d.Set("credential_id", NullableF64ToStr(resp["credential"]))
 	d.Set("scm_update_on_launch", resp["scm_update_on_launch"])
 	d.Set("allow_override", resp["allow_override"])
 	return nil
 }
 
-func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
@@ -184,20 +199,31 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	updateData["scm_update_on_launch"] = d.Get("scm_update_on_launch")
 	updateData["allow_override"] = d.Get("allow_override")
 
-	_, err := clientInstance.Put(fmt.Sprintf("/api/v2/projects/%s/", id), updateData)
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/projects/%s/", id), updateData)
 	if err != nil {
-		return fmt.Errorf("failed to update AWX project: %s, %v", err, updateData)
+		return diagFromErr("failed to update AWX project", err, projectFields)
 	}
-	return resourceProjectRead(d, m)
+	return resourceProjectRead(ctx, d, m)
 }
 
-func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	clientInstance := m.(*Client)
 	id := d.Id()
 
-	err := clientInstance.Delete(fmt.Sprintf("/api/v2/projects/%s/", id))
+	// AWX refuses to delete a project while one of its updates is running, so
+	// keep trying until the update finishes or the delete timeout expires.
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		err := clientInstance.Delete(ctx, fmt.Sprintf("/projects/%s/", id))
+		if apiErr, ok := AsAPIError(err); ok && apiErr.IsConflict() {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete AWX project: %s", err)
+		return diag.Errorf("failed to delete AWX project: %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// roleResourceCollections maps the resource types roles can be granted on to
+// their AWX API collections.
+var roleResourceCollections = map[string]string{
+	"credential":            "credentials",
+	"instance_group":        "instance_groups",
+	"inventory":             "inventories",
+	"job_template":          "job_templates",
+	"organization":          "organizations",
+	"project":               "projects",
+	"team":                  "teams",
+	"workflow_job_template": "workflow_job_templates",
+}
+
+func ResourceRoleAssignment() *schema.Resource {
+	resourceTypes := make([]string, 0, len(roleResourceCollections))
+	for resourceType := range roleResourceCollections {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	return &schema.Resource{
+		CreateContext: resourceRoleAssignmentCreate,
+		ReadContext:   resourceRoleAssignmentRead,
+		DeleteContext: resourceRoleAssignmentDelete,
+		Description: "Grants a role on an Ansible AWX/Tower object to a user or a team, e.g. 'execute' on a job template " +
+			"or 'use' on a credential. The role is looked up in the object's summary_fields.object_roles. Removing the " +
+			"resource revokes the role.",
+
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(resourceTypes, false),
+				Description:  "The type of the object the role belongs to. One of 'credential', 'instance_group', 'inventory', 'job_template', 'organization', 'project', 'team' or 'workflow_job_template'.",
+			},
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the object the role belongs to.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"admin", "execute", "use", "read", "update", "adhoc", "member", "approval", "auditor"}, false),
+				Description:  "The name of the role to grant, e.g. 'admin', 'execute', 'use', 'read', 'update' or 'adhoc'. The available roles depend on the resource type.",
+			},
+			"user_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				ExactlyOneOf: []string{"user_id", "team_id"},
+				Description:  "The ID of the user to grant the role to. Conflicts with team_id.",
+			},
+			"team_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				ExactlyOneOf: []string{"user_id", "team_id"},
+				Description:  "The ID of the team to grant the role to. Conflicts with user_id.",
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the AWX/Tower role that was granted.",
+			},
+		},
+	}
+}
+
+// roleAssignmentMembers returns the role sub-list the principal is associated
+// through and the principal's ID.
+func roleAssignmentMembers(d *schema.ResourceData, roleID string) (string, string) {
+	if userID := d.Get("user_id").(string); userID != "" {
+		return fmt.Sprintf("/roles/%s/users/", roleID), userID
+	}
+	return fmt.Sprintf("/roles/%s/teams/", roleID), d.Get("team_id").(string)
+}
+
+func resourceRoleAssignmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	resourceType := d.Get("resource_type").(string)
+	role := d.Get("role").(string)
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/%s/%s/", roleResourceCollections[resourceType], d.Get("resource_id")))
+	if err != nil {
+		return diag.Errorf("failed to read AWX %s: %s", resourceType, err)
+	}
+	summaryFields, _ := resp["summary_fields"].(map[string]interface{})
+	objectRoles, _ := summaryFields["object_roles"].(map[string]interface{})
+	objectRole, ok := objectRoles[role+"_role"].(map[string]interface{})
+	if !ok {
+		return diag.Errorf("AWX %s %s has no %q role", resourceType, d.Get("resource_id"), role)
+	}
+	roleID := F64ToStr(objectRole["id"])
+
+	path, principalID := roleAssignmentMembers(d, roleID)
+	if err := clientInstance.Associate(ctx, path, principalID); err != nil {
+		return diagFromErr("failed to grant AWX role", err, nil)
+	}
+
+	d.Set("role_id", roleID)
+	d.SetId(fmt.Sprintf("%s_%s", roleID, principalID))
+	return resourceRoleAssignmentRead(ctx, d, m)
+}
+
+func resourceRoleAssignmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	path, principalID := roleAssignmentMembers(d, d.Get("role_id").(string))
+
+	members, err := clientInstance.GetAllIDs(ctx, path)
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX role members: %s", err)
+	}
+
+	for _, member := range members {
+		if member == principalID {
+			return nil
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	path, principalID := roleAssignmentMembers(d, d.Get("role_id").(string))
+
+	err := clientInstance.Disassociate(ctx, path, principalID)
+	if err != nil && !clientInstance.IsNotFound(err) {
+		return diag.Errorf("failed to revoke AWX role: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTeam() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamCreate,
+		ReadContext:   resourceTeamRead,
+		UpdateContext: resourceTeamUpdate,
+		DeleteContext: resourceTeamDelete,
+		Importer:      importByNamedURL("/teams/"),
+		Description: "Manages an Ansible AWX/Tower team. A team is a subdivision of an organization with associated users, " +
+			"projects, credentials, and permissions. Teams provide a means to implement role-based access control schemes " +
+			"and delegate responsibilities across organizations.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this team. Used to identify the team in the AWX/Tower interface.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Optional description of this team. Can be used to provide more context about the team's purpose.",
+			},
+			"organization": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the organization the team belongs to, e.g. from an awx_organization resource or data source.",
+			},
+		},
+	}
+}
+
+func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	data := map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"description":  d.Get("description").(string),
+		"organization": IfaceToInt(d.Get("organization")),
+	}
+
+	resp, err := clientInstance.Post(ctx, "/teams/", data)
+	if err != nil {
+		return diagFromErr("failed to create AWX team", err, nil)
+	}
+
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return diag.Errorf("AWX API did not return an id %v", resp)
+	}
+	d.SetId(fmt.Sprintf("%.0f", id))
+	return resourceTeamRead(ctx, d, m)
+}
+
+func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/teams/%s/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX team: %s", err)
+	}
+
+	d.Set("name", resp["name"].(string))
+	d.Set("description", resp["description"].(string))
+	d.Set("organization", F64ToStr(resp["organization"]))
+	return nil
+}
+
+func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	data := map[string]interface{}{}
+	data["name"] = d.Get("name").(string)
+	data["description"] = d.Get("description").(string)
+	data["organization"] = IfaceToInt(d.Get("organization"))
+
+	_, err := clientInstance.Put(ctx, fmt.Sprintf("/teams/%s/", id), data)
+	if err != nil {
+		return diagFromErr("failed to update AWX team", err, nil)
+	}
+	return resourceTeamRead(ctx, d, m)
+}
+
+func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/teams/%s/", id))
+	if err != nil {
+		return diag.Errorf("failed to delete AWX team: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
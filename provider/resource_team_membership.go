@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTeamMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamMembershipCreate,
+		ReadContext:   resourceTeamMembershipRead,
+		UpdateContext: resourceTeamMembershipUpdate,
+		DeleteContext: resourceTeamMembershipDelete,
+		Importer:      importByNamedURL("/teams/"),
+		Description: "Manages the members of an Ansible AWX/Tower team. This resource is authoritative: users added to the " +
+			"team outside of Terraform are reported as drift and removed on the next apply. Members inherit every role " +
+			"granted to the team.",
+
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: StringIsID,
+				Description:  "The ID of the team whose members are managed.",
+			},
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: StringIsID,
+				},
+				Description: "The IDs of the users that are members of the team.",
+			},
+		},
+	}
+}
+
+func resourceTeamMembershipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	teamID := d.Get("team_id").(string)
+
+	for _, userID := range d.Get("user_ids").(*schema.Set).List() {
+		err := clientInstance.Associate(ctx, fmt.Sprintf("/teams/%s/users/", teamID), userID.(string))
+		if err != nil {
+			return diag.Errorf("failed to add user %s to AWX team: %s", userID, err)
+		}
+	}
+
+	d.SetId(teamID)
+	return resourceTeamMembershipRead(ctx, d, m)
+}
+
+func resourceTeamMembershipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	userIDs, err := clientInstance.GetAllIDs(ctx, fmt.Sprintf("/teams/%s/users/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX team members: %s", err)
+	}
+
+	d.Set("team_id", id)
+	d.Set("user_ids", userIDs)
+	return nil
+}
+
+func resourceTeamMembershipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	path := fmt.Sprintf("/teams/%s/users/", d.Id())
+
+	o, n := d.GetChange("user_ids")
+	oldUsers, newUsers := o.(*schema.Set), n.(*schema.Set)
+	for _, userID := range oldUsers.Difference(newUsers).List() {
+		err := clientInstance.Disassociate(ctx, path, userID.(string))
+		if err != nil && !clientInstance.IsNotFound(err) {
+			return diag.Errorf("failed to remove user %s from AWX team: %s", userID, err)
+		}
+	}
+	for _, userID := range newUsers.Difference(oldUsers).List() {
+		err := clientInstance.Associate(ctx, path, userID.(string))
+		if err != nil {
+			return diag.Errorf("failed to add user %s to AWX team: %s", userID, err)
+		}
+	}
+	return resourceTeamMembershipRead(ctx, d, m)
+}
+
+func resourceTeamMembershipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	path := fmt.Sprintf("/teams/%s/users/", d.Id())
+
+	for _, userID := range d.Get("user_ids").(*schema.Set).List() {
+		err := clientInstance.Disassociate(ctx, path, userID.(string))
+		if err != nil && !clientInstance.IsNotFound(err) {
+			return diag.Errorf("failed to remove user %s from AWX team: %s", userID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+		Importer:      importByNamedURL("/users/"),
+		Description: "Manages an Ansible AWX/Tower user. Users can be granted roles directly or through team membership. " +
+			"Users provisioned by LDAP, SAML or other external authentication are tolerated: the fields owned by the " +
+			"identity provider (email, first and last name, password) are neither refreshed nor sent to AWX/Tower.",
+
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The username used to log in. Used to identify the user in the AWX/Tower interface.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the user. Required by AWX/Tower for locally authenticated users. It is only sent when the user is created or when the value changes, and is never read back.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The email address of the user.",
+			},
+			"first_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The first name of the user.",
+			},
+			"last_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The last name of the user.",
+			},
+			"is_superuser": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, the user has full administrative access to AWX/Tower.",
+			},
+			"is_system_auditor": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If enabled, the user has read-only access to every object in AWX/Tower.",
+			},
+			"external_account": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The external authentication source managing this user (e.g. 'ldap' or 'social'), or an empty string for local users.",
+			},
+		},
+	}
+}
+
+// userExternalFields are owned by the identity provider for external accounts.
+var userExternalFields = []string{"email", "first_name", "last_name"}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	data := map[string]interface{}{
+		"username":          d.Get("username").(string),
+		"email":             d.Get("email").(string),
+		"first_name":        d.Get("first_name").(string),
+		"last_name":         d.Get("last_name").(string),
+		"is_superuser":      d.Get("is_superuser"),
+		"is_system_auditor": d.Get("is_system_auditor"),
+	}
+	if d.Get("password") != "" {
+		data["password"] = d.Get("password").(string)
+	}
+
+	resp, err := clientInstance.Post(ctx, "/users/", data)
+	if err != nil {
+		return diagFromErr("failed to create AWX user", err, nil)
+	}
+
+	id, ok := resp["id"].(float64)
+	if !ok {
+		return diag.Errorf("AWX API did not return an id %v", resp)
+	}
+	d.SetId(fmt.Sprintf("%.0f", id))
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	resp, err := clientInstance.Get(ctx, fmt.Sprintf("/users/%s/", id))
+	if err != nil {
+		if clientInstance.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read AWX user: %s", err)
+	}
+
+	external := userExternalAccount(resp)
+	d.Set("username", resp["username"].(string))
+	d.Set("is_superuser", resp["is_superuser"])
+	d.Set("is_system_auditor", resp["is_system_auditor"])
+	d.Set("external_account", external)
+	if external == "" {
+		for _, field := range userExternalFields {
+			value, _ := resp[field].(string)
+			d.Set(field, value)
+		}
+	}
+	return nil
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+	external := d.Get("external_account").(string) != ""
+
+	data := map[string]interface{}{}
+	data["username"] = d.Get("username").(string)
+	data["is_superuser"] = d.Get("is_superuser")
+	data["is_system_auditor"] = d.Get("is_system_auditor")
+	if !external {
+		for _, field := range userExternalFields {
+			data[field] = d.Get(field).(string)
+		}
+		if d.HasChange("password") && d.Get("password") != "" {
+			data["password"] = d.Get("password").(string)
+		}
+	}
+
+	_, err := clientInstance.Patch(ctx, fmt.Sprintf("/users/%s/", id), data)
+	if err != nil {
+		return diagFromErr("failed to update AWX user", err, nil)
+	}
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientInstance := m.(*Client)
+	id := d.Id()
+
+	err := clientInstance.Delete(ctx, fmt.Sprintf("/users/%s/", id))
+	if err != nil {
+		return diag.Errorf("failed to delete AWX user: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// userExternalAccount returns the authentication source managing the user, or
+// an empty string for users authenticated by AWX itself.
+func userExternalAccount(resp map[string]interface{}) string {
+	if external, ok := resp["external_account"].(string); ok && external != "" {
+		return external
+	}
+	if ldapDN, ok := resp["ldap_dn"].(string); ok && ldapDN != "" {
+		return "ldap"
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// sessions tracks clients holding a short-lived OAuth2 token created at
+// configure time, so the tokens can be revoked when the plugin exits.
+var sessions sessionRegistry
+
+// sessionRevokeTimeout bounds token revocation at shutdown. Terraform kills
+// the plugin process two seconds after asking it to stop, so revocation has to
+// finish well within that window.
+const sessionRevokeTimeout = time.Second
+
+type sessionRegistry struct {
+	mu      sync.Mutex
+	clients []*Client
+}
+
+func (r *sessionRegistry) add(c *Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients = append(r.clients, c)
+}
+
+// Shutdown revokes every OAuth2 token the provider created for its session.
+// It is called from main once the plugin server has stopped. Revocation is
+// best-effort: each token gets a single attempt within sessionRevokeTimeout,
+// and tokens that could not be revoked in time are left to expire in AWX.
+func Shutdown() {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionRevokeTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, c := range sessions.clients {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			c.MaxRetries = 0
+			if err := c.RevokeToken(ctx); err != nil {
+				log.Printf("[WARN] failed to revoke AWX session token: %s", err)
+			}
+		}(c)
+	}
+	wg.Wait()
+	sessions.clients = nil
+}
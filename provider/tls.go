@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// TLSOptions describes how the client verifies AWX and authenticates to it.
+type TLSOptions struct {
+	// CACertFile and CACertPEM add trusted CA certificates on top of the system pool.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey are PEM-encoded and enable mutual TLS.
+	ClientCert string
+	ClientKey  string
+
+	InsecureSkipVerify bool
+}
+
+func (o TLSOptions) isZero() bool {
+	return o == TLSOptions{}
+}
+
+// Config builds the *tls.Config described by the options.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CACertFile != "" || o.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if o.CACertFile != "" {
+			pem, err := ioutil.ReadFile(o.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %s", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid certificates found in %s", o.CACertFile)
+			}
+		}
+		if o.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(o.CACertPEM)) {
+			return nil, errors.New("no valid certificates found in ca_cert_pem")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.ClientCert != "" || o.ClientKey != "" {
+		if o.ClientCert == "" || o.ClientKey == "" {
+			return nil, errors.New("client_cert and client_key must be provided together")
+		}
+		cert, err := tls.X509KeyPair([]byte(o.ClientCert), []byte(o.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %s", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// SetTLSOptions configures the client's transport with the given TLS options.
+func (c *Client) SetTLSOptions(o TLSOptions) error {
+	if o.isZero() {
+		return nil
+	}
+	cfg, err := o.Config()
+	if err != nil {
+		return err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	c.Client.Transport = transport
+	return nil
+}
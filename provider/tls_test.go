@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTLSTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version": "24.0.0"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTLSTestClient(t *testing.T, server *httptest.Server, o TLSOptions) *Client {
+	t.Helper()
+	c, err := NewClient(server.URL, "token", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.APIPrefix = defaultAPIPrefix
+	c.MaxRetries = 0
+	if err := c.SetTLSOptions(o); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestTLSCustomCA(t *testing.T) {
+	server := newTLSTestServer(t)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	c := newTLSTestClient(t, server, TLSOptions{CACertPEM: string(caPEM)})
+	if _, err := c.Get(context.Background(), "/ping/"); err != nil {
+		t.Fatalf("request with ca_cert_pem failed: %s", err)
+	}
+}
+
+func TestTLSUnknownCA(t *testing.T) {
+	server := newTLSTestServer(t)
+
+	c := newTLSTestClient(t, server, TLSOptions{})
+	_, err := c.Get(context.Background(), "/ping/")
+	var unknownAuthority x509.UnknownAuthorityError
+	if !errors.As(err, &unknownAuthority) {
+		t.Fatalf("expected an unknown certificate authority error, got %v", err)
+	}
+}
+
+func TestTLSInsecureSkipVerify(t *testing.T) {
+	server := newTLSTestServer(t)
+
+	c := newTLSTestClient(t, server, TLSOptions{InsecureSkipVerify: true})
+	if _, err := c.Get(context.Background(), "/ping/"); err != nil {
+		t.Fatalf("request with insecure_skip_verify failed: %s", err)
+	}
+}
+
+// newClientCertificate returns a self-signed client certificate and its key,
+// both PEM-encoded.
+func newClientCertificate(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+// newMutualTLSTestServer returns a server that requires a client certificate
+// signed by clientCA, along with the PEM of its own certificate.
+func newMutualTLSTestServer(t *testing.T, clientCA string) (*httptest.Server, string) {
+	t.Helper()
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(clientCA)) {
+		t.Fatal("invalid client CA")
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version": "24.0.0"}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	certPEM, keyPEM := newClientCertificate(t)
+	server, caPEM := newMutualTLSTestServer(t, certPEM)
+
+	c := newTLSTestClient(t, server, TLSOptions{CACertPEM: caPEM, ClientCert: certPEM, ClientKey: keyPEM})
+	if _, err := c.Get(context.Background(), "/ping/"); err != nil {
+		t.Fatalf("request with client_cert and client_key failed: %s", err)
+	}
+}
+
+func TestTLSMissingClientCertificate(t *testing.T) {
+	certPEM, _ := newClientCertificate(t)
+	server, caPEM := newMutualTLSTestServer(t, certPEM)
+
+	c := newTLSTestClient(t, server, TLSOptions{CACertPEM: caPEM})
+	if _, err := c.Get(context.Background(), "/ping/"); err == nil {
+		t.Fatal("request without a client certificate succeeded against a server requiring one")
+	}
+}
+
+func TestTLSInvalidClientCertificate(t *testing.T) {
+	certPEM, _ := newClientCertificate(t)
+	_, otherKeyPEM := newClientCertificate(t)
+
+	cases := []struct {
+		name string
+		o    TLSOptions
+		want string
+	}{
+		{"mismatched key", TLSOptions{ClientCert: certPEM, ClientKey: otherKeyPEM}, "failed to load client certificate"},
+		{"malformed key", TLSOptions{ClientCert: certPEM, ClientKey: "not a key"}, "failed to load client certificate"},
+		{"certificate only", TLSOptions{ClientCert: certPEM}, "client_cert and client_key must be provided together"},
+		{"key only", TLSOptions{ClientKey: otherKeyPEM}, "client_cert and client_key must be provided together"},
+	}
+	for _, tc := range cases {
+		c, err := NewClient("https://awx.example.com", "token", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = c.SetTLSOptions(tc.o)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: SetTLSOptions returned %v, want an error containing %q", tc.name, err, tc.want)
+		}
+	}
+}